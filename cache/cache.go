@@ -0,0 +1,118 @@
+// Package cache provides thread-safe generic caches with a fixed capacity.
+//
+// LRU evicts the least recently used entry when it is full, LFU the least
+// frequently used one (and among those, the least recently used). Both are
+// built on the module's deque.Deque: a map finds the deque.Cursor of an
+// entry, and a use moves the entry to the front of its deque by removing
+// it through the cursor and pushing it back, both O(1), so every operation
+// is O(1).
+//
+// Example usage:
+//
+//	c := cache.NewLRU[string, int](2, cache.WithOnEvict(func(k string, v int) {
+//		fmt.Println("evicted", k)
+//	}))
+//	c.Put("a", 1)
+//	c.Put("b", 2)
+//	c.Get("a")
+//	c.Put("c", 3)          // prints "evicted b"
+//	val, ok := c.Get("b")  // returns 0, false
+package cache
+
+// Cache is the interface implemented by LRU and LFU
+type Cache[K comparable, V any] interface {
+	// Get returns the value stored under key and records the access
+	Get(key K) (V, bool)
+	// Peek returns the value stored under key without recording an access
+	Peek(key K) (V, bool)
+	// Put stores value under key, evicting an entry if the cache is full
+	Put(key K, value V) bool
+	// Remove removes the entry stored under key
+	Remove(key K) bool
+	// Resize changes the capacity, evicting entries that no longer fit
+	Resize(capacity int) int
+	// Purge removes every entry
+	Purge()
+	Len() int
+	Cap() int
+	Stats() Stats
+}
+
+var (
+	_ Cache[int, int] = (*LRU[int, int])(nil)
+	_ Cache[int, int] = (*LFU[int, int])(nil)
+)
+
+// Stats holds the counters of a cache
+type Stats struct {
+	Hits      uint64 // calls to Get that found the key
+	Misses    uint64 // calls to Get that did not find the key
+	Evictions uint64 // entries evicted to respect the capacity
+}
+
+// HitRatio returns the fraction of calls to Get that found the key,
+// or 0 if Get was never called
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Option configures a cache created by NewLRU or NewLFU
+type Option[K comparable, V any] func(*config[K, V])
+
+// config holds the settings applied by the options
+type config[K comparable, V any] struct {
+	onEvict func(key K, value V)
+}
+
+// WithOnEvict registers fn to be called for every entry the cache evicts
+// to respect its capacity, in Put or Resize. Entries removed by Remove or
+// Purge are not reported. fn is called after the cache has released its
+// lock, so it may use the cache
+func WithOnEvict[K comparable, V any](fn func(key K, value V)) Option[K, V] {
+	return func(cfg *config[K, V]) {
+		cfg.onEvict = fn
+	}
+}
+
+// newConfig applies opts to a default config
+func newConfig[K comparable, V any](opts []Option[K, V]) config[K, V] {
+	var cfg config[K, V]
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	return cfg
+}
+
+// entry is a key-value pair stored by a cache
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// notify reports the evicted entries to the callback, if any.
+// Caller must not hold the cache lock
+func (cfg *config[K, V]) notify(evicted ...entry[K, V]) {
+	if cfg.onEvict == nil {
+		return
+	}
+	for _, e := range evicted {
+		cfg.onEvict(e.key, e.value)
+	}
+}
+
+// checkCapacity panics if capacity is not positive
+func checkCapacity(capacity int) {
+	if capacity <= 0 {
+		panic("cache: capacity must be positive")
+	}
+}
+
+// zeroval returns the zero value for type T
+func zeroval[T any]() T {
+	var zero T
+	return zero
+}
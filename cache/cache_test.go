@@ -0,0 +1,161 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// implementations returns a constructor for every cache of the package
+func implementations() map[string]func(capacity int, opts ...Option[int, int]) Cache[int, int] {
+	return map[string]func(int, ...Option[int, int]) Cache[int, int]{
+		"LRU": func(capacity int, opts ...Option[int, int]) Cache[int, int] {
+			return NewLRU(capacity, opts...)
+		},
+		"LFU": func(capacity int, opts ...Option[int, int]) Cache[int, int] {
+			return NewLFU(capacity, opts...)
+		},
+	}
+}
+
+func TestCache_Common(t *testing.T) {
+	for name, newCache := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			c := newCache(3)
+			assert.Equal(t, 3, c.Cap())
+			assert.Equal(t, 0, c.Len())
+
+			_, ok := c.Get(1)
+			assert.False(t, ok)
+
+			assert.False(t, c.Put(1, 10))
+			assert.False(t, c.Put(2, 20))
+			assert.False(t, c.Put(1, 11)) // update, no eviction
+
+			val, ok := c.Get(1)
+			assert.True(t, ok)
+			assert.Equal(t, 11, val)
+
+			val, ok = c.Peek(2)
+			assert.True(t, ok)
+			assert.Equal(t, 20, val)
+			assert.Equal(t, 2, c.Len())
+
+			assert.True(t, c.Remove(2))
+			assert.False(t, c.Remove(2))
+			_, ok = c.Peek(2)
+			assert.False(t, ok)
+
+			c.Purge()
+			assert.Equal(t, 0, c.Len())
+
+			assert.Equal(t, Stats{Hits: 1, Misses: 1}, c.Stats())
+			assert.Panics(t, func() { newCache(0) })
+			assert.Panics(t, func() { c.Resize(-1) })
+		})
+	}
+}
+
+func TestCache_OnEvict(t *testing.T) {
+	for name, newCache := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			var c Cache[int, int]
+			var evicted []int
+
+			c = newCache(2, WithOnEvict(func(key, value int) {
+				assert.Equal(t, key*10, value)
+				evicted = append(evicted, key)
+
+				// The callback runs outside of the lock, so it may use the cache
+				_, ok := c.Peek(key)
+				assert.False(t, ok)
+			}))
+
+			c.Put(1, 10)
+			c.Put(2, 20)
+			assert.True(t, c.Put(3, 30))
+			assert.Equal(t, []int{1}, evicted)
+
+			// Removed and purged entries are not reported
+			c.Remove(2)
+			c.Purge()
+			assert.Equal(t, []int{1}, evicted)
+
+			c.Put(4, 40)
+			c.Put(5, 50)
+			assert.Equal(t, 1, c.Resize(1))
+			assert.Equal(t, []int{1, 4}, evicted)
+			assert.Equal(t, 1, c.Cap())
+			assert.Equal(t, uint64(2), c.Stats().Evictions)
+		})
+	}
+}
+
+func TestStats_HitRatio(t *testing.T) {
+	assert.Equal(t, 0.0, Stats{}.HitRatio())
+	assert.Equal(t, 0.75, Stats{Hits: 3, Misses: 1}.HitRatio())
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	for name, newCache := range implementations() {
+		t.Run(name, func(t *testing.T) {
+			c := newCache(64)
+
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(seed int64) {
+					defer wg.Done()
+					rng := rand.New(rand.NewSource(seed))
+					for i := 0; i < 2000; i++ {
+						key := rng.Intn(128)
+						switch rng.Intn(4) {
+						case 0:
+							c.Put(key, key)
+						case 1:
+							c.Remove(key)
+						default:
+							if val, ok := c.Get(key); ok {
+								assert.Equal(t, key, val)
+							}
+						}
+					}
+				}(int64(g))
+			}
+			wg.Wait()
+
+			assert.LessOrEqual(t, c.Len(), 64)
+			stats := c.Stats()
+			assert.NotZero(t, stats.Hits+stats.Misses)
+		})
+	}
+}
+
+func BenchmarkCache_Parallel(b *testing.B) {
+	const capacity = 1024
+
+	for name, newCache := range implementations() {
+		for _, keys := range []int{capacity / 2, capacity * 4} {
+			b.Run(fmt.Sprintf("%s/keys=%d", name, keys), func(b *testing.B) {
+				c := newCache(capacity)
+				for i := 0; i < keys; i++ {
+					c.Put(i, i)
+				}
+
+				b.ResetTimer()
+				b.RunParallel(func(pb *testing.PB) {
+					rng := rand.New(rand.NewSource(rand.Int63()))
+					for pb.Next() {
+						key := rng.Intn(keys)
+						if _, ok := c.Get(key); !ok {
+							c.Put(key, key)
+						}
+					}
+				})
+			})
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/Pshimaf-Git/container/deque"
+)
+
+// lfuEntry is an entry of an LFU cache along with its use count and its
+// position in the bucket of that count
+type lfuEntry[K comparable, V any] struct {
+	entry[K, V]
+	freq uint64
+	cur  *deque.Cursor[*lfuEntry[K, V]]
+}
+
+// LFU is a thread-safe cache that evicts the least frequently used entry
+// once it holds more entries than its capacity; ties are broken by evicting
+// the least recently used of them.
+// Get and Put count as a use of the key, Peek does not. An entry's count
+// starts at one when it is added and is forgotten once it leaves the cache
+type LFU[K comparable, V any] struct {
+	mu       sync.RWMutex
+	capacity int
+	items    map[K]*lfuEntry[K, V]
+	stats    Stats
+	cfg      config[K, V]
+
+	// buckets holds, for each use count, the entries used that many times,
+	// the most recently used at the front. Empty buckets are dropped
+	buckets map[uint64]*deque.Deque[*lfuEntry[K, V]]
+	minFreq uint64 // lowest use count in buckets, 0 if unknown
+}
+
+// NewLFU creates and returns a new empty LFU cache holding at most
+// capacity entries. It panics if capacity is not positive
+func NewLFU[K comparable, V any](capacity int, opts ...Option[K, V]) *LFU[K, V] {
+	checkCapacity(capacity)
+
+	return &LFU[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*lfuEntry[K, V]),
+		buckets:  make(map[uint64]*deque.Deque[*lfuEntry[K, V]]),
+		cfg:      newConfig(opts),
+	}
+}
+
+// Get returns the value stored under key and counts a use of the key.
+// Returns the zero value and false if the key is not cached
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return zeroval[V](), false
+	}
+
+	c.stats.Hits++
+	c.touch(e)
+	return e.value, true
+}
+
+// Peek returns the value stored under key without counting a use or
+// updating the stats. Returns the zero value and false if the key is not
+// cached
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return zeroval[V](), false
+	}
+	return e.value, true
+}
+
+// Contains reports whether key is cached, without counting a use
+func (c *LFU[K, V]) Contains(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Frequency returns the number of uses counted for key, or 0 if the key
+// is not cached
+func (c *LFU[K, V]) Frequency(key K) uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return 0
+	}
+	return e.freq
+}
+
+// Put stores value under key and counts a use of the key.
+// If the cache is full, the least frequently used entry is evicted first.
+// It reports whether an entry was evicted
+func (c *LFU[K, V]) Put(key K, value V) bool {
+	c.mu.Lock()
+	evicted, ok := c.put(key, value)
+	c.mu.Unlock()
+
+	if ok {
+		c.cfg.notify(evicted)
+	}
+	return ok
+}
+
+// Remove removes the entry stored under key and reports whether it was
+// cached
+func (c *LFU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	e.cur.Remove()
+	c.dropIfEmpty(e.freq)
+	delete(c.items, key)
+	return true
+}
+
+// Resize changes the capacity of the cache, evicting the least frequently
+// used entries that no longer fit, and returns the count of evicted
+// entries. It panics if capacity is not positive
+func (c *LFU[K, V]) Resize(capacity int) int {
+	checkCapacity(capacity)
+
+	c.mu.Lock()
+	c.capacity = capacity
+	var evicted []entry[K, V]
+	for len(c.items) > c.capacity {
+		evicted = append(evicted, c.evict())
+	}
+	c.mu.Unlock()
+
+	c.cfg.notify(evicted...)
+	return len(evicted)
+}
+
+// Purge removes every entry from the cache. The stats are kept
+func (c *LFU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*lfuEntry[K, V])
+	c.buckets = make(map[uint64]*deque.Deque[*lfuEntry[K, V]])
+	c.minFreq = 0
+}
+
+// Len returns the number of cached entries
+func (c *LFU[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.items)
+}
+
+// Cap returns the maximum number of entries the cache holds
+func (c *LFU[K, V]) Cap() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.capacity
+}
+
+// Stats returns a copy of the counters of the cache
+func (c *LFU[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stats
+}
+
+// put stores value under key and returns the entry evicted to make room,
+// if any. Caller must hold the write lock
+func (c *LFU[K, V]) put(key K, value V) (entry[K, V], bool) {
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(e)
+		return entry[K, V]{}, false
+	}
+
+	var evicted entry[K, V]
+	full := len(c.items) >= c.capacity
+	if full {
+		evicted = c.evict()
+	}
+
+	e := &lfuEntry[K, V]{entry: entry[K, V]{key: key, value: value}, freq: 1}
+	c.items[key] = e
+	c.pushFront(e)
+	c.minFreq = 1
+	return evicted, full
+}
+
+// touch moves e to the bucket of the next use count.
+// Caller must hold the write lock
+func (c *LFU[K, V]) touch(e *lfuEntry[K, V]) {
+	wasMin := c.minFreq == e.freq
+	e.cur.Remove()
+	c.dropIfEmpty(e.freq)
+
+	e.freq++
+	c.pushFront(e)
+
+	// e was alone in the lowest bucket, so its new bucket is the lowest
+	if wasMin && c.minFreq == 0 {
+		c.minFreq = e.freq
+	}
+}
+
+// evict removes and returns the least recently used of the least
+// frequently used entries.
+// Assumes the cache is not empty and caller holds the write lock
+func (c *LFU[K, V]) evict() entry[K, V] {
+	if c.minFreq == 0 {
+		c.minFreq = c.lowestFreq()
+	}
+
+	e, _ := c.buckets[c.minFreq].PopBack()
+	c.dropIfEmpty(e.freq)
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	return e.entry
+}
+
+// pushFront adds e to the front of the bucket of its use count, creating
+// the bucket if needed. Caller must hold the write lock
+func (c *LFU[K, V]) pushFront(e *lfuEntry[K, V]) {
+	bucket, ok := c.buckets[e.freq]
+	if !ok {
+		bucket = deque.New[*lfuEntry[K, V]]()
+		c.buckets[e.freq] = bucket
+	}
+
+	// Buckets are unbounded and never closed, so the push cannot fail
+	e.cur, _ = bucket.PushFrontHandle(e)
+}
+
+// dropIfEmpty drops the bucket of use count freq if it holds no entries.
+// Dropping the bucket of the lowest count leaves that count unknown until
+// an eviction needs it. Caller must hold the write lock
+func (c *LFU[K, V]) dropIfEmpty(freq uint64) {
+	if !c.buckets[freq].IsEmpty() {
+		return
+	}
+
+	delete(c.buckets, freq)
+	if c.minFreq == freq {
+		c.minFreq = 0
+	}
+}
+
+// lowestFreq returns the lowest use count that has a bucket. It scans the
+// buckets, which evict only needs once Remove or Resize emptied the
+// bucket of the lowest count. Caller must hold the write lock
+func (c *LFU[K, V]) lowestFreq() uint64 {
+	var lowest uint64
+	for freq := range c.buckets {
+		if lowest == 0 || freq < lowest {
+			lowest = freq
+		}
+	}
+	return lowest
+}
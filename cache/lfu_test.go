@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFU_Eviction(t *testing.T) {
+	c := NewLFU[string, int](3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+
+	c.Get("a")
+	c.Get("a")
+	c.Put("b", 20)
+	c.Peek("c") // does not count
+
+	assert.Equal(t, uint64(3), c.Frequency("a"))
+	assert.Equal(t, uint64(2), c.Frequency("b"))
+	assert.Equal(t, uint64(1), c.Frequency("c"))
+	assert.Equal(t, uint64(0), c.Frequency("x"))
+
+	assert.True(t, c.Put("d", 4))
+	assert.False(t, c.Contains("c"))
+
+	// "d" is now the least frequently used entry
+	assert.True(t, c.Put("e", 5))
+	assert.False(t, c.Contains("d"))
+	assert.True(t, c.Contains("a"))
+	assert.True(t, c.Contains("b"))
+}
+
+func TestLFU_TieBreak(t *testing.T) {
+	c := NewLFU[int, int](3)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)
+	c.Get(2)
+	c.Get(3)
+
+	// All keys were used twice, so the least recently used goes first
+	c.Put(4, 4)
+	assert.False(t, c.Contains(1))
+
+	// A removed key forgets its count
+	c.Remove(2)
+	c.Put(2, 2)
+	assert.Equal(t, uint64(1), c.Frequency(2))
+}
+
+func TestLFU_Resize(t *testing.T) {
+	c := NewLFU[int, int](4)
+	for i := 0; i < 4; i++ {
+		c.Put(i, i)
+		for j := 0; j < i; j++ {
+			c.Get(i)
+		}
+	}
+
+	assert.Equal(t, 2, c.Resize(2))
+	assert.False(t, c.Contains(0))
+	assert.False(t, c.Contains(1))
+	assert.True(t, c.Contains(2))
+	assert.True(t, c.Contains(3))
+}
+
+func TestLFU_RemoveLowest(t *testing.T) {
+	c := NewLFU[int, int](3)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(2)
+	c.Get(3)
+	c.Get(3)
+
+	// Removing the only entry used once leaves the lowest count to be
+	// found again by the next eviction
+	assert.True(t, c.Remove(1))
+	assert.Equal(t, 1, c.Resize(1))
+	assert.False(t, c.Contains(2))
+	assert.True(t, c.Contains(3))
+	assert.Equal(t, uint64(3), c.Frequency(3))
+
+	assert.True(t, c.Put(4, 4))
+	assert.False(t, c.Contains(3))
+	assert.Equal(t, uint64(1), c.Frequency(4))
+}
@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"sync"
+
+	"github.com/Pshimaf-Git/container/deque"
+)
+
+// LRU is a thread-safe cache that evicts the least recently used entry
+// once it holds more entries than its capacity.
+// Get and Put count as a use of the key, Peek does not
+type LRU[K comparable, V any] struct {
+	mu       sync.RWMutex
+	capacity int
+	items    map[K]*deque.Cursor[entry[K, V]]
+	order    *deque.Deque[entry[K, V]] // most recently used at the front
+	stats    Stats
+	cfg      config[K, V]
+}
+
+// NewLRU creates and returns a new empty LRU cache holding at most
+// capacity entries. It panics if capacity is not positive
+func NewLRU[K comparable, V any](capacity int, opts ...Option[K, V]) *LRU[K, V] {
+	checkCapacity(capacity)
+
+	return &LRU[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*deque.Cursor[entry[K, V]]),
+		order:    deque.New[entry[K, V]](),
+		cfg:      newConfig(opts),
+	}
+}
+
+// Get returns the value stored under key and marks the key as the most
+// recently used. Returns the zero value and false if the key is not cached
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cur, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		return zeroval[V](), false
+	}
+
+	c.stats.Hits++
+	e, _ := cur.Remove()
+	c.pushFront(e)
+	return e.value, true
+}
+
+// Peek returns the value stored under key without marking it as used or
+// updating the stats. Returns the zero value and false if the key is not
+// cached
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	cur, ok := c.items[key]
+	if !ok {
+		return zeroval[V](), false
+	}
+
+	e, _ := cur.Value()
+	return e.value, true
+}
+
+// Contains reports whether key is cached, without marking it as used
+func (c *LRU[K, V]) Contains(key K) bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	_, ok := c.items[key]
+	return ok
+}
+
+// Put stores value under key and marks the key as the most recently used.
+// If the cache is full, the least recently used entry is evicted first.
+// It reports whether an entry was evicted
+func (c *LRU[K, V]) Put(key K, value V) bool {
+	c.mu.Lock()
+	evicted, ok := c.put(key, value)
+	c.mu.Unlock()
+
+	if ok {
+		c.cfg.notify(evicted)
+	}
+	return ok
+}
+
+// Remove removes the entry stored under key and reports whether it was
+// cached
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cur, ok := c.items[key]
+	if !ok {
+		return false
+	}
+
+	cur.Remove()
+	delete(c.items, key)
+	return true
+}
+
+// Resize changes the capacity of the cache, evicting the least recently
+// used entries that no longer fit, and returns the count of evicted
+// entries. It panics if capacity is not positive
+func (c *LRU[K, V]) Resize(capacity int) int {
+	checkCapacity(capacity)
+
+	c.mu.Lock()
+	c.capacity = capacity
+	var evicted []entry[K, V]
+	for len(c.items) > c.capacity {
+		evicted = append(evicted, c.evict())
+	}
+	c.mu.Unlock()
+
+	c.cfg.notify(evicted...)
+	return len(evicted)
+}
+
+// Purge removes every entry from the cache. The stats are kept
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*deque.Cursor[entry[K, V]])
+	c.order.Clear()
+}
+
+// Keys returns the cached keys from the most to the least recently used
+func (c *LRU[K, V]) Keys() []K {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	keys := make([]K, 0, len(c.items))
+	for e := range c.order.Values() {
+		keys = append(keys, e.key)
+	}
+	return keys
+}
+
+// Len returns the number of cached entries
+func (c *LRU[K, V]) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return len(c.items)
+}
+
+// Cap returns the maximum number of entries the cache holds
+func (c *LRU[K, V]) Cap() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.capacity
+}
+
+// Stats returns a copy of the counters of the cache
+func (c *LRU[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.stats
+}
+
+// put stores value under key and returns the entry evicted to make room,
+// if any. Caller must hold the write lock
+func (c *LRU[K, V]) put(key K, value V) (entry[K, V], bool) {
+	if cur, ok := c.items[key]; ok {
+		cur.Remove()
+		c.pushFront(entry[K, V]{key: key, value: value})
+		return entry[K, V]{}, false
+	}
+
+	var evicted entry[K, V]
+	full := len(c.items) >= c.capacity
+	if full {
+		evicted = c.evict()
+	}
+
+	c.pushFront(entry[K, V]{key: key, value: value})
+	return evicted, full
+}
+
+// pushFront adds e as the most recently used entry.
+// Caller must hold the write lock
+func (c *LRU[K, V]) pushFront(e entry[K, V]) {
+	// The order deque is unbounded and never closed, so the push cannot fail
+	c.items[e.key], _ = c.order.PushFrontHandle(e)
+}
+
+// evict removes and returns the least recently used entry.
+// Assumes the cache is not empty and caller holds the write lock
+func (c *LRU[K, V]) evict() entry[K, V] {
+	e, _ := c.order.PopBack()
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	return e
+}
@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRU_Eviction(t *testing.T) {
+	c := NewLRU[string, int](3)
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	assert.Equal(t, []string{"c", "b", "a"}, c.Keys())
+
+	// Get and Put refresh a key, Peek and Contains do not
+	c.Get("a")
+	c.Put("b", 20)
+	c.Peek("c")
+	assert.True(t, c.Contains("c"))
+	assert.Equal(t, []string{"b", "a", "c"}, c.Keys())
+
+	assert.True(t, c.Put("d", 4))
+	assert.False(t, c.Contains("c"))
+	assert.Equal(t, []string{"d", "b", "a"}, c.Keys())
+}
+
+func TestLRU_Resize(t *testing.T) {
+	c := NewLRU[int, int](4)
+	for i := 0; i < 4; i++ {
+		c.Put(i, i)
+	}
+
+	assert.Equal(t, 2, c.Resize(2))
+	assert.Equal(t, []int{3, 2}, c.Keys())
+
+	assert.Equal(t, 0, c.Resize(5))
+	for i := 4; i < 7; i++ {
+		assert.False(t, c.Put(i, i))
+	}
+	assert.Equal(t, 5, c.Len())
+}
+
+// TestLRU_Order checks the recency order against a slice model through a
+// random mix of operations
+func TestLRU_Order(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	c := NewLRU[int, int](16)
+	model := []int{} // most recently used first
+
+	use := func(key int) {
+		model = slices.DeleteFunc(model, func(k int) bool { return k == key })
+		model = slices.Insert(model, 0, key)
+	}
+
+	for op := 0; op < 5000; op++ {
+		key := rng.Intn(40)
+		switch rng.Intn(3) {
+		case 0:
+			_, ok := c.Get(key)
+			assert.Equal(t, slices.Contains(model, key), ok)
+			if ok {
+				use(key)
+			}
+		case 1:
+			c.Put(key, key)
+			use(key)
+			if len(model) > 16 {
+				model = model[:16]
+			}
+		case 2:
+			assert.Equal(t, slices.Contains(model, key), c.Remove(key))
+			model = slices.DeleteFunc(model, func(k int) bool { return k == key })
+		}
+
+		if !assert.Equal(t, model, c.Keys(), "op %d", op) {
+			return
+		}
+	}
+}
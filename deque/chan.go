@@ -0,0 +1,100 @@
+package deque
+
+import "context"
+
+// FromChan returns a new deque that is filled from ch by a managed
+// goroutine. A positive capacity makes the deque bounded with the
+// OverflowBlock policy, so the goroutine stops receiving from ch while
+// the deque is full; a capacity of 0 makes it unbounded.
+// The deque is closed once ch is closed or ctx is done, which lets
+// consumers using PopFrontWait or Drain detect the end of the input
+func FromChan[T any](ctx context.Context, ch <-chan T, capacity int) *Deque[T] {
+	d := New[T]()
+	if capacity > 0 {
+		d = NewBounded[T](capacity, OverflowBlock)
+	}
+
+	go d.fill(ctx, ch)
+
+	return d
+}
+
+// fill pushes every value received from ch to the back of the deque
+// and closes the deque when ch is closed or ctx is done
+func (d *Deque[T]) fill(ctx context.Context, ch <-chan T) {
+	// Closing the deque also releases a push blocked on a full deque
+	stop := context.AfterFunc(ctx, d.Close)
+	defer stop()
+	defer d.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case v, ok := <-ch:
+			if !ok {
+				return
+			}
+			if err := d.PushBack(v); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// Chan returns a channel that receives the elements of the deque from
+// front to back, fed by a managed goroutine. The goroutine holds at
+// most one popped element while it waits for a receiver, so a slow
+// receiver leaves the rest of the elements in the deque.
+// The channel is closed once the deque is closed and drained, or when
+// ctx is done. An element popped but not yet delivered at that point is
+// put back to the front of the deque, following the rules of restoreFront:
+// it is dropped if the deque has been drained after being closed, or if
+// it is full and does not evict
+func (d *Deque[T]) Chan(ctx context.Context) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		for {
+			val, err := d.PopFrontWait(ctx)
+			if err != nil {
+				return
+			}
+
+			select {
+			case out <- val:
+			case <-ctx.Done():
+				d.restoreFront(val)
+				return
+			}
+		}
+	}()
+
+	return out
+}
+
+// restoreFront puts back a value that was popped from the front but
+// could not be delivered, and reports whether it did. The value is
+// dropped if the deque is closed and drained, since consumers may already
+// have seen ErrClosed, and if the deque is full, unless its policy is
+// OverflowEvict, which drops the back element instead as PushFront would
+func (d *Deque[T]) restoreFront(val T) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed && d.count == 0 {
+		return false
+	}
+	if d.capacity > 0 && d.count >= d.capacity {
+		if d.policy != OverflowEvict {
+			return false
+		}
+		d.popBack()
+	}
+
+	d.pushFront([]T{val})
+	d.signalNotEmpty(1)
+	return true
+}
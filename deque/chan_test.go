@@ -0,0 +1,153 @@
+package deque
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromChan(t *testing.T) {
+	t.Run("closes deque when channel is closed", func(t *testing.T) {
+		ch := make(chan int)
+		d := FromChan(context.Background(), ch, 0)
+
+		go func() {
+			for i := 0; i < 5; i++ {
+				ch <- i
+			}
+			close(ch)
+		}()
+
+		var result []int
+		for v := range d.Drain() {
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{0, 1, 2, 3, 4}, result)
+		assert.True(t, d.IsClosed())
+	})
+
+	t.Run("applies backpressure when bounded", func(t *testing.T) {
+		ch := make(chan int, 10)
+		for i := 0; i < 10; i++ {
+			ch <- i
+		}
+		close(ch)
+
+		d := FromChan(context.Background(), ch, 3)
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, 3, d.Len())
+
+		var result []int
+		for v := range d.Drain() {
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, result)
+	})
+
+	t.Run("closes deque when context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int)
+		d := FromChan(ctx, ch, 1)
+
+		ch <- 1
+		ch <- 2 // stays blocked in PushBack until cancel
+
+		cancel()
+		_, err := d.PopFrontWait(context.Background())
+		assert.NoError(t, err)
+
+		assert.Eventually(t, d.IsClosed, time.Second, time.Millisecond)
+	})
+}
+
+func TestDeque_Chan(t *testing.T) {
+	t.Run("delivers elements until closed", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+		d.Close()
+
+		var result []int
+		for v := range d.Chan(context.Background()) {
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{1, 2, 3}, result)
+	})
+
+	t.Run("stops on cancel and restores undelivered element", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := d.Chan(ctx)
+		assert.Equal(t, 1, <-ch)
+
+		// Let the goroutine pop the next element and wait for a receiver
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+
+		for range ch {
+		}
+		assert.Equal(t, []int{2, 3}, d.ToArray())
+	})
+
+	t.Run("keeps a bounded deque within capacity", func(t *testing.T) {
+		d := NewBounded[int](1, OverflowBlock)
+		assert.NoError(t, d.PushBack(1))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := d.Chan(ctx)
+
+		// Refill the deque while the goroutine holds the popped element
+		assert.NoError(t, d.PushBack(2))
+		cancel()
+
+		for range ch {
+		}
+		assert.Equal(t, []int{2}, d.ToArray())
+		assert.LessOrEqual(t, d.Len(), d.Cap())
+	})
+}
+
+func TestDeque_RestoreFront(t *testing.T) {
+	t.Run("open deque", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1))
+		assert.True(t, d.restoreFront(0))
+		assert.Equal(t, []int{0, 1}, d.ToArray())
+	})
+
+	t.Run("closed deque with elements left", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1))
+		d.Close()
+		assert.True(t, d.restoreFront(0))
+		assert.Equal(t, []int{0, 1}, d.ToArray())
+	})
+
+	t.Run("closed and drained deque", func(t *testing.T) {
+		d := New[int]()
+		d.Close()
+		assert.False(t, d.restoreFront(0))
+
+		_, err := d.PopFront()
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("full bounded deque", func(t *testing.T) {
+		for _, policy := range []OverflowPolicy{OverflowBlock, OverflowReject} {
+			d := NewBounded[int](2, policy)
+			assert.NoError(t, d.PushBack(1, 2))
+			assert.False(t, d.restoreFront(0))
+			assert.Equal(t, []int{1, 2}, d.ToArray())
+		}
+	})
+
+	t.Run("full evicting deque", func(t *testing.T) {
+		d := NewBounded[int](2, OverflowEvict)
+		assert.NoError(t, d.PushBack(1, 2))
+		assert.True(t, d.restoreFront(0))
+		assert.Equal(t, []int{0, 1}, d.ToArray())
+	})
+}
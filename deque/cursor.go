@@ -0,0 +1,319 @@
+package deque
+
+import "fmt"
+
+// Cursor is a stable reference to an element of a Deque. It follows the
+// element while other elements are pushed, popped, inserted or removed
+// around it, and becomes invalid once the element leaves the deque or the
+// deque is cleared or sorted.
+//
+// Every method goes through the deque's lock, so cursors are safe for
+// concurrent use. There is at most one Cursor per element, so two cursors
+// refer to the same element exactly when they are equal.
+//
+// Reading, writing and removing through a cursor is O(1). Removing an
+// element from the middle leaves a hole in the ring buffer instead of
+// shifting its neighbours. Pops and navigation skip the holes, pops and
+// buffer resizes reclaim them, and the first operation that addresses
+// elements by position afterwards (Get, Insert, CursorAt, Index, ...)
+// compacts the deque in O(n).
+// Inserting through a cursor shifts the elements between it and the
+// closer end of the deque, as Insert does, so it is O(1) near either end
+// and O(n) in the middle
+type Cursor[T any] struct {
+	d    *Deque[T]
+	slot int // index in d.buf, -1 once the cursor is invalid
+}
+
+// PushFrontHandle adds value to the front of the deque, like PushFront,
+// and returns a cursor to it
+func (d *Deque[T]) PushFrontHandle(value T) (*Cursor[T], error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).PushFrontHandle"
+
+	if d.closed {
+		return nil, fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+
+	values, err := d.admit(fancName, []T{value}, false)
+	if err != nil {
+		return nil, err
+	}
+
+	d.pushFront(values)
+	d.signalNotEmpty(1)
+
+	return d.cursorAt(d.head), nil
+}
+
+// PushBackHandle adds value to the back of the deque, like PushBack,
+// and returns a cursor to it
+func (d *Deque[T]) PushBackHandle(value T) (*Cursor[T], error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).PushBackHandle"
+
+	if d.closed {
+		return nil, fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+
+	values, err := d.admit(fancName, []T{value}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	d.pushBack(values)
+	d.signalNotEmpty(1)
+
+	return d.cursorAt(d.tail()), nil
+}
+
+// CursorAt returns a cursor to the element at the specified index.
+// Returns ErrIndexOutOfRange if the index is not in [0, Len())
+func (d *Deque[T]) CursorAt(index int) (*Cursor[T], error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).CursorAt"
+
+	if index < 0 || index >= d.count {
+		return nil, fmt.Errorf("%s: %w", fancName, ErrIndexOutOfRange)
+	}
+
+	d.compact()
+	return d.cursorAt(d.at(index)), nil
+}
+
+// Valid reports whether the cursor still refers to an element of the deque
+func (c *Cursor[T]) Valid() bool {
+	c.d.mu.RLock()
+	defer c.d.mu.RUnlock()
+
+	return c.slot >= 0
+}
+
+// Index returns the current position of the element in the deque,
+// or -1 if the cursor is no longer valid
+func (c *Cursor[T]) Index() int {
+	c.d.rlockCompacted()
+	defer c.d.mu.RUnlock()
+
+	if c.slot < 0 {
+		return -1
+	}
+	return c.d.indexOf(c.slot)
+}
+
+// Value returns the element the cursor refers to.
+// Returns ErrInvalidCursor if the cursor is no longer valid
+func (c *Cursor[T]) Value() (T, error) {
+	c.d.mu.RLock()
+	defer c.d.mu.RUnlock()
+	const fancName = "(*Cursor[T]).Value"
+
+	if c.slot < 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrInvalidCursor)
+	}
+	return c.d.buf[c.slot], nil
+}
+
+// Set replaces the element the cursor refers to with value.
+// Returns ErrInvalidCursor if the cursor is no longer valid
+func (c *Cursor[T]) Set(value T) error {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	const fancName = "(*Cursor[T]).Set"
+
+	if c.slot < 0 {
+		return fmt.Errorf("%s: %w", fancName, ErrInvalidCursor)
+	}
+
+	c.d.buf[c.slot] = value
+	c.d.version++
+	return nil
+}
+
+// Next returns a cursor to the element after the one c refers to,
+// or nil if c refers to the back element or is no longer valid
+func (c *Cursor[T]) Next() *Cursor[T] {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+
+	if c.slot < 0 || c.slot == c.d.tail() {
+		return nil
+	}
+	return c.d.cursorAt(c.d.nextLive(c.slot))
+}
+
+// Prev returns a cursor to the element before the one c refers to,
+// or nil if c refers to the front element or is no longer valid
+func (c *Cursor[T]) Prev() *Cursor[T] {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+
+	if c.slot < 0 || c.slot == c.d.head {
+		return nil
+	}
+	return c.d.cursorAt(c.d.prevLive(c.slot))
+}
+
+// InsertBefore inserts value right before the element the cursor refers
+// to and returns a cursor to it. It follows the rules of Insert and also
+// returns ErrInvalidCursor if the cursor is no longer valid
+func (c *Cursor[T]) InsertBefore(value T) (*Cursor[T], error) {
+	return c.insert("(*Cursor[T]).InsertBefore", value, 0)
+}
+
+// InsertAfter inserts value right after the element the cursor refers
+// to and returns a cursor to it. It follows the rules of Insert and also
+// returns ErrInvalidCursor if the cursor is no longer valid
+func (c *Cursor[T]) InsertAfter(value T) (*Cursor[T], error) {
+	return c.insert("(*Cursor[T]).InsertAfter", value, 1)
+}
+
+// Remove removes the element the cursor refers to from the deque and
+// returns it in O(1). The cursor becomes invalid.
+// Returns ErrInvalidCursor if the cursor is no longer valid
+func (c *Cursor[T]) Remove() (T, error) {
+	c.d.mu.Lock()
+	defer c.d.mu.Unlock()
+	const fancName = "(*Cursor[T]).Remove"
+
+	if c.slot < 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrInvalidCursor)
+	}
+	return c.d.removeSlot(c.slot), nil
+}
+
+// insert inserts value at offset positions after the element the cursor
+// refers to and returns a cursor to it
+func (c *Cursor[T]) insert(fancName string, value T, offset int) (*Cursor[T], error) {
+	d := c.d
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if c.slot < 0 {
+		return nil, fmt.Errorf("%s: %w", fancName, ErrInvalidCursor)
+	}
+
+	values := []T{value}
+	if err := d.admitInsert(fancName, values); err != nil {
+		return nil, err
+	}
+
+	// admitInsert may have waited for room, releasing the lock meanwhile
+	if c.slot < 0 {
+		return nil, fmt.Errorf("%s: %w", fancName, ErrInvalidCursor)
+	}
+
+	d.compact()
+	index := d.indexOf(c.slot) + offset
+	d.insertAt(index, values)
+	d.signalNotEmpty(1)
+
+	return d.cursorAt(d.at(index)), nil
+}
+
+// cursorAt returns the cursor to the element in buffer slot, creating it
+// if needed. Caller must hold the write lock
+func (d *Deque[T]) cursorAt(slot int) *Cursor[T] {
+	if d.cursors == nil {
+		d.cursors = make([]*Cursor[T], len(d.buf))
+	}
+
+	c := d.cursors[slot]
+	if c == nil {
+		c = &Cursor[T]{d: d, slot: slot}
+		d.cursors[slot] = c
+	}
+	return c
+}
+
+// indexOf maps a buffer slot to the logical position of its element.
+// Assumes the deque has no holes and caller holds the lock
+func (d *Deque[T]) indexOf(slot int) int {
+	return (slot - d.head) & (len(d.buf) - 1)
+}
+
+// removeSlot removes and returns the element in buffer slot. The front
+// and back elements are popped, while any other one leaves a hole behind
+// instead of shifting its neighbours. Caller must hold the write lock
+func (d *Deque[T]) removeSlot(slot int) T {
+	switch slot {
+	case d.head:
+		return d.popFront()
+	case d.tail():
+		return d.popBack()
+	}
+
+	val := d.buf[slot]
+	d.untag(slot)
+	d.buf[slot] = zeroval[T]() // allow GC of the removed value
+
+	if d.dead == nil {
+		d.dead = make([]bool, len(d.buf))
+	}
+	d.dead[slot] = true
+	d.holes++
+	d.count--
+	d.version++
+	d.shrink()
+	d.signalNotFull()
+
+	return val
+}
+
+// move moves the element in buffer slot src, along with its cursor, to
+// slot dst, leaving src without a cursor. Caller must hold the write lock
+func (d *Deque[T]) move(dst, src int) {
+	d.buf[dst] = d.buf[src]
+
+	if d.cursors != nil {
+		c := d.cursors[src]
+		d.cursors[dst], d.cursors[src] = c, nil
+		if c != nil {
+			c.slot = dst
+		}
+	}
+}
+
+// swapSlots exchanges the elements in buffer slots i and j along with
+// their cursors. Caller must hold the write lock
+func (d *Deque[T]) swapSlots(i, j int) {
+	d.buf[i], d.buf[j] = d.buf[j], d.buf[i]
+
+	if d.cursors != nil {
+		ci, cj := d.cursors[i], d.cursors[j]
+		d.cursors[i], d.cursors[j] = cj, ci
+		if ci != nil {
+			ci.slot = j
+		}
+		if cj != nil {
+			cj.slot = i
+		}
+	}
+}
+
+// untag invalidates the cursor to the element in buffer slot, which is
+// about to leave the deque. Caller must hold the write lock
+func (d *Deque[T]) untag(slot int) {
+	if d.cursors == nil {
+		return
+	}
+
+	if c := d.cursors[slot]; c != nil {
+		c.slot = -1
+		d.cursors[slot] = nil
+	}
+}
+
+// invalidateCursors invalidates every cursor of the deque.
+// Caller must hold the write lock
+func (d *Deque[T]) invalidateCursors() {
+	for _, c := range d.cursors {
+		if c != nil {
+			c.slot = -1
+		}
+	}
+	d.cursors = nil
+}
@@ -0,0 +1,381 @@
+package deque
+
+import (
+	"cmp"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursor_Basic(t *testing.T) {
+	d := New[int]()
+	c2, err := d.PushBackHandle(2)
+	assert.NoError(t, err)
+	c1, err := d.PushFrontHandle(1)
+	assert.NoError(t, err)
+	c3, err := d.PushBackHandle(3)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 3}, d.ToArray())
+	assert.Equal(t, 0, c1.Index())
+	assert.Equal(t, 1, c2.Index())
+	assert.Equal(t, 2, c3.Index())
+
+	val, err := c2.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, val)
+
+	assert.NoError(t, c2.Set(20))
+	assert.Equal(t, []int{1, 20, 3}, d.ToArray())
+
+	// Navigation returns the same cursor for the same element
+	assert.Same(t, c2, c1.Next())
+	assert.Same(t, c3, c2.Next())
+	assert.Same(t, c1, c2.Prev())
+	assert.Nil(t, c3.Next())
+	assert.Nil(t, c1.Prev())
+
+	c, err := d.CursorAt(1)
+	assert.NoError(t, err)
+	assert.Same(t, c2, c)
+
+	_, err = d.CursorAt(3)
+	assert.ErrorIs(t, err, ErrIndexOutOfRange)
+}
+
+func TestCursor_Insert(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 3, 5))
+
+	c, err := d.CursorAt(1)
+	assert.NoError(t, err)
+
+	before, err := c.InsertBefore(2)
+	assert.NoError(t, err)
+	after, err := c.InsertAfter(4)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, d.ToArray())
+	assert.Equal(t, 1, before.Index())
+	assert.Equal(t, 2, c.Index())
+	assert.Equal(t, 3, after.Index())
+	assert.Same(t, c, before.Next())
+	assert.Same(t, after, c.Next())
+
+	t.Run("bounded", func(t *testing.T) {
+		d := NewBounded[int](2, OverflowReject)
+		c, err := d.PushBackHandle(1)
+		assert.NoError(t, err)
+
+		_, err = c.InsertAfter(2)
+		assert.NoError(t, err)
+		_, err = c.InsertAfter(3)
+		assert.ErrorIs(t, err, ErrFullQueue)
+		assert.Equal(t, []int{1, 2}, d.ToArray())
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		d := New[int]()
+		c, err := d.PushBackHandle(1)
+		assert.NoError(t, err)
+		d.Close()
+
+		_, err = c.InsertBefore(0)
+		assert.ErrorIs(t, err, ErrClosed)
+		_, err = d.PushBackHandle(2)
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+
+	t.Run("blocked insert of a removed cursor", func(t *testing.T) {
+		d := NewBounded[int](2, OverflowBlock)
+		assert.NoError(t, d.PushBack(1, 2))
+		c, err := d.CursorAt(1)
+		assert.NoError(t, err)
+
+		done := make(chan error)
+		go func() {
+			_, err := c.InsertBefore(0)
+			done <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		_, err = d.PopBack()
+		assert.NoError(t, err)
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		case <-time.After(time.Second):
+			t.Fatal("InsertBefore did not return after room was made")
+		}
+	})
+}
+
+func TestCursor_Remove(t *testing.T) {
+	d := New[int]()
+	cursors := make([]*Cursor[int], 5)
+	for i := range cursors {
+		c, err := d.PushBackHandle(i)
+		assert.NoError(t, err)
+		cursors[i] = c
+	}
+
+	val, err := cursors[1].Remove()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.False(t, cursors[1].Valid())
+	assert.Equal(t, -1, cursors[1].Index())
+
+	val, err = cursors[3].Remove()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, val)
+
+	assert.Equal(t, []int{0, 2, 4}, d.ToArray())
+	assert.Equal(t, 0, cursors[0].Index())
+	assert.Equal(t, 1, cursors[2].Index())
+	assert.Equal(t, 2, cursors[4].Index())
+
+	_, err = cursors[1].Remove()
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = cursors[1].Value()
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	assert.ErrorIs(t, cursors[1].Set(0), ErrInvalidCursor)
+	assert.Nil(t, cursors[1].Next())
+	assert.Nil(t, cursors[1].Prev())
+}
+
+func TestCursor_RemoveLeavesHole(t *testing.T) {
+	d := New[int]()
+	cursors := make([]*Cursor[int], 5)
+	for i := range cursors {
+		cursors[i], _ = d.PushBackHandle(i)
+	}
+	slots := make([]int, len(cursors))
+	for i, c := range cursors {
+		slots[i] = c.slot
+	}
+
+	// Removing from the middle moves no other element
+	_, err := cursors[2].Remove()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, d.holes)
+	for i, c := range cursors {
+		if i != 2 {
+			assert.Equal(t, slots[i], c.slot)
+		}
+	}
+
+	assert.Equal(t, 4, d.Len())
+	assert.Equal(t, []int{0, 1, 3, 4}, d.ToArray())
+	assert.Same(t, cursors[3], cursors[1].Next())
+	assert.Same(t, cursors[1], cursors[3].Prev())
+
+	// Pops skip the hole, leaving none behind
+	_, err = cursors[1].Remove()
+	assert.NoError(t, err)
+	for _, want := range []int{0, 3} {
+		val, err := d.PopFront()
+		assert.NoError(t, err)
+		assert.Equal(t, want, val)
+	}
+	assert.Equal(t, 0, d.holes)
+	assert.Equal(t, []int{4}, d.ToArray())
+
+	// Positional access compacts the deque
+	_, err = d.PushFrontHandle(-1)
+	assert.NoError(t, err)
+	c, _ := d.PushFrontHandle(-2)
+	assert.NoError(t, d.PushFront(-3))
+	c.Remove()
+	assert.Equal(t, 1, d.holes)
+	val, ok := d.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, -1, val)
+	assert.Equal(t, 0, d.holes)
+	assert.Equal(t, 2, cursors[4].Index())
+}
+
+// TestCursor_MoveToFront runs the access pattern of an LRU cache, which
+// removes elements from the middle and pushes them back to the front,
+// and checks that the holes do not make the buffer grow without bound
+func TestCursor_MoveToFront(t *testing.T) {
+	const n = 100
+
+	rng := rand.New(rand.NewSource(1))
+	d := New[int]()
+	cursors := make([]*Cursor[int], n)
+	for i := range cursors {
+		cursors[i], _ = d.PushFrontHandle(i)
+	}
+
+	for op := 0; op < 10000; op++ {
+		i := rng.Intn(n)
+		val, err := cursors[i].Remove()
+		assert.NoError(t, err)
+		assert.Equal(t, i, val)
+		cursors[i], err = d.PushFrontHandle(i)
+		assert.NoError(t, err)
+	}
+
+	assert.Equal(t, n, d.Len())
+	assert.LessOrEqual(t, len(d.buf), 4*n)
+
+	values := d.ToArray()
+	for i, c := range cursors {
+		assert.Equal(t, i, values[c.Index()])
+	}
+}
+
+func TestCursor_Invalidation(t *testing.T) {
+	t.Run("pop", func(t *testing.T) {
+		d := New[int]()
+		front, _ := d.PushBackHandle(1)
+		back, _ := d.PushBackHandle(2)
+
+		d.PopFront()
+		assert.False(t, front.Valid())
+		assert.True(t, back.Valid())
+
+		d.PopBack()
+		assert.False(t, back.Valid())
+	})
+
+	t.Run("clear", func(t *testing.T) {
+		d := New[int]()
+		c, _ := d.PushBackHandle(1)
+		d.Clear()
+		assert.False(t, c.Valid())
+
+		// The deque can be used with new cursors afterwards
+		c, err := d.PushBackHandle(2)
+		assert.NoError(t, err)
+		assert.Equal(t, 0, c.Index())
+	})
+
+	t.Run("sort", func(t *testing.T) {
+		d := New[int]()
+		c, _ := d.PushBackHandle(2)
+		assert.NoError(t, d.PushBack(1))
+		SortFunc(d, cmp.Compare[int])
+		assert.False(t, c.Valid())
+	})
+
+	t.Run("remove func", func(t *testing.T) {
+		d := New[int]()
+		cursors := make([]*Cursor[int], 6)
+		for i := range cursors {
+			cursors[i], _ = d.PushBackHandle(i)
+		}
+
+		d.RemoveFunc(func(v int) bool { return v%2 == 0 })
+		for i, c := range cursors {
+			if i%2 == 0 {
+				assert.False(t, c.Valid())
+				continue
+			}
+			val, err := c.Value()
+			assert.NoError(t, err)
+			assert.Equal(t, i, val)
+			assert.Equal(t, i/2, c.Index())
+		}
+	})
+}
+
+// TestCursor_FollowsElements checks that cursors keep referring to their
+// elements through every kind of reordering and through buffer resizes
+func TestCursor_FollowsElements(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	d := New[int]()
+	cursors := map[int]*Cursor[int]{}
+	next := 0
+
+	for op := 0; op < 3000; op++ {
+		switch n := d.Len(); rng.Intn(11) {
+		case 0:
+			c, err := d.PushFrontHandle(next)
+			assert.NoError(t, err)
+			cursors[next] = c
+			next++
+		case 1:
+			c, err := d.PushBackHandle(next)
+			assert.NoError(t, err)
+			cursors[next] = c
+			next++
+		case 2:
+			assert.NoError(t, d.Insert(rng.Intn(n+1), next, next+1))
+			next += 2
+		case 3:
+			if n > 0 {
+				d.RemoveAt(rng.Intn(n))
+			}
+		case 4:
+			if n > 0 {
+				d.PopFront()
+			}
+		case 5:
+			d.Rotate(rng.Intn(7) - 3)
+		case 6:
+			if n > 1 {
+				assert.NoError(t, d.Swap(rng.Intn(n), rng.Intn(n)))
+			}
+		case 7:
+			if n > 0 {
+				c, err := d.CursorAt(rng.Intn(n))
+				assert.NoError(t, err)
+				val, _ := c.Value()
+				cursors[val] = c
+			}
+		case 8:
+			for _, c := range cursors {
+				if c.Valid() && rng.Intn(3) == 0 {
+					val, _ := c.Value()
+					c.Remove()
+					delete(cursors, val)
+					break
+				}
+			}
+		case 9:
+			if n > 0 {
+				d.PopBack()
+			}
+		case 10:
+			for _, c := range cursors {
+				if c.Valid() && rng.Intn(4) == 0 {
+					val, _ := c.Value()
+					_, err := c.InsertAfter(next)
+					assert.NoError(t, err)
+					next++
+					c.Remove()
+					delete(cursors, val)
+					break
+				}
+			}
+		}
+
+		if op%23 == 0 {
+			d.Reverse()
+		}
+
+		values := d.ToArray()
+		present := map[int]bool{}
+		for _, v := range values {
+			present[v] = true
+		}
+
+		for val, c := range cursors {
+			if !assert.Equal(t, present[val], c.Valid(), "op %d", op) {
+				return
+			}
+			if !c.Valid() {
+				delete(cursors, val)
+				continue
+			}
+			index := c.Index()
+			if !assert.Equal(t, val, values[index], "op %d", op) {
+				return
+			}
+		}
+	}
+}
@@ -1,31 +1,114 @@
-// package deque is a linked list based implementation of a double
-// ended queue
+// package deque is a ring buffer based implementation of a double
+// ended queue. It also provides WorkStealing, a lock-free Chase–Lev
+// deque for task schedulers
 package deque
 
 import (
-	"container/list"
+	"context"
 	"errors"
 	"fmt"
 	"iter"
 	"sync"
+	"sync/atomic"
 )
 
 var (
-	ErrTypeAssertion = errors.New("type assertion failed")
-	ErrEmptyQueue    = errors.New("queue is empty")
+	// Deprecated: ErrTypeAssertion is never returned since the deque
+	// stores values of type T directly. It is kept for compatibility
+	ErrTypeAssertion   = errors.New("type assertion failed")
+	ErrEmptyQueue      = errors.New("queue is empty")
+	ErrClosed          = errors.New("queue is closed")
+	ErrFullQueue       = errors.New("queue is full")
+	ErrIndexOutOfRange = errors.New("index out of range")
+	ErrInvalidCursor   = errors.New("cursor is no longer valid")
+
+	// ErrConcurrentModification is reported (wrapped) by the error function
+	// of IterChecked when the deque changes under the iteration
+	ErrConcurrentModification = errors.New("deque modified during iteration")
 )
 
+// OverflowPolicy decides what a bounded deque does when a push would
+// exceed its capacity
+type OverflowPolicy int
+
+const (
+	// OverflowBlock makes pushes wait until the values fit
+	OverflowBlock OverflowPolicy = iota
+	// OverflowReject makes pushes fail with ErrFullQueue
+	OverflowReject
+	// OverflowEvict removes elements from the opposite end to make room,
+	// turning the deque into a sliding window
+	OverflowEvict
+)
+
+// IterMode selects how an iterator returned by Iter protects itself
+// against modifications of the deque while it is running.
+// Only Iter takes a mode: Iterator, DescendingIterator, Backward, Values,
+// Range and RangeBackward always iterate over a snapshot, and IterChecked
+// provides fail-fast iteration
+type IterMode int
+
+const (
+	// IterSnapshot copies the elements under the read lock and iterates
+	// over the copy without holding any lock. The loop body may freely
+	// access and modify the deque; changes are not observed by the loop.
+	// It costs one allocation of Len() elements
+	IterSnapshot IterMode = iota
+	// IterLocked holds the read lock for the whole loop. Other readers
+	// proceed concurrently while writers wait for the loop to end. The loop
+	// body must not modify the deque, which would deadlock, and should not
+	// call back into it at all, since a read lock taken while a writer is
+	// waiting also deadlocks
+	IterLocked
+)
+
+// minCapacity is the smallest size of the underlying buffer once the
+// deque holds at least one element. It must be a power of two
+const minCapacity = 16
+
 // Deque represents a double-ended queue (deque) data structure
-// that is thread-safe and generic over type T
+// that is thread-safe and generic over type T.
+// Elements are stored in a growable circular buffer whose size is
+// always a power of two, so indexing is O(1) and pushes do not
+// allocate per element. Removing an element through a Cursor leaves a
+// hole in the buffer instead of shifting its neighbours; see Cursor
 type Deque[T any] struct {
-	list *list.List
-	mu   sync.RWMutex
+	buf   []T
+	head  int // buffer index of the front element
+	count int // number of elements stored in buf
+	mu    sync.RWMutex
+
+	holes int    // empty slots between the front and the back element
+	dead  []bool // parallel to buf, marks the holes; nil while there are none
+
+	capacity int // maximum number of elements, 0 means unbounded
+	policy   OverflowPolicy
+
+	closed   bool
+	notEmpty *sync.Cond // tied to mu, created on first use
+	notFull  *sync.Cond // tied to mu, created on first use
+
+	version uint64 // incremented by every modification of the elements
+
+	cursors []*Cursor[T] // parallel to buf, nil until a cursor is created
 }
 
 // New creates and returns a new empty instance of Deque
 func New[T any]() *Deque[T] {
+	return &Deque[T]{}
+}
+
+// NewBounded creates and returns a new empty Deque that holds at most
+// capacity elements. The policy decides what pushes do once the
+// deque is full. It panics if capacity is not positive
+func NewBounded[T any](capacity int, policy OverflowPolicy) *Deque[T] {
+	if capacity <= 0 {
+		panic("deque: capacity must be positive")
+	}
+
 	return &Deque[T]{
-		list: list.New(),
+		capacity: capacity,
+		policy:   policy,
 	}
 }
 
@@ -40,7 +123,7 @@ func (d *Deque[T]) Len() int {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	return d.list.Len()
+	return d.count
 }
 
 // IsEmpty returns true if the deque contains no elements
@@ -48,112 +131,194 @@ func (d *Deque[T]) IsEmpty() bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	return d.list.Len() == 0
+	return d.count == 0
+}
+
+// Cap returns the maximum number of elements the deque can hold,
+// or 0 if the deque is unbounded
+func (d *Deque[T]) Cap() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.capacity
 }
 
 // PushFront adds one or more values to the front of the deque
-// in reverse order (last input becomes first in deque)
-func (d *Deque[T]) PushFront(values ...T) {
+// in reverse order (last input becomes first in deque).
+// On a bounded deque the values are pushed as a single batch according
+// to the overflow policy: OverflowBlock waits until all of them fit,
+// OverflowReject fails with ErrFullQueue, and OverflowEvict drops
+// elements from the back (keeping the first Cap() values if there are
+// more of them than the capacity). A batch larger than the capacity
+// can never fit and fails with ErrFullQueue under the first two policies
+func (d *Deque[T]) PushFront(values ...T) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).PushFront"
 
-	for i := len(values) - 1; i >= 0; i-- {
-		d.list.PushFront(values[i])
+	if d.closed {
+		return fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+
+	values, err := d.admit(fancName, values, false)
+	if err != nil {
+		return err
 	}
+
+	d.pushFront(values)
+	d.signalNotEmpty(len(values))
+
+	return nil
 }
 
 // PushBack appends one or more values to the end of the deque
-// in the same order they were provided
-func (d *Deque[T]) PushBack(values ...T) {
+// in the same order they were provided.
+// On a bounded deque the values are pushed as a single batch according
+// to the overflow policy: OverflowBlock waits until all of them fit,
+// OverflowReject fails with ErrFullQueue, and OverflowEvict drops
+// elements from the front (keeping the last Cap() values if there are
+// more of them than the capacity). A batch larger than the capacity
+// can never fit and fails with ErrFullQueue under the first two policies
+func (d *Deque[T]) PushBack(values ...T) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).PushBack"
 
-	for _, v := range values {
-		d.list.PushBack(v)
+	if d.closed {
+		return fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+
+	values, err := d.admit(fancName, values, true)
+	if err != nil {
+		return err
 	}
+
+	d.pushBack(values)
+	d.signalNotEmpty(len(values))
+
+	return nil
 }
 
 // PopFront removes and returns the first element from the deque.
-// Returns an error if the deque is empty or type assertion fails.
+// Returns ErrEmptyQueue if the deque is empty, or ErrClosed if it is
+// empty and closed.
 func (d *Deque[T]) PopFront() (T, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	const fancName = "(*Deque[T]).PopFront"
 
-	if d.list.Len() == 0 {
-		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	if d.count == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, d.emptyErr())
 	}
 
-	elem := d.list.Front()
-	defer d.list.Remove(elem)
-
-	val, ok := elem.Value.(T)
-	if !ok {
-		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrTypeAssertion)
-	}
-	return val, nil
+	return d.popFront(), nil
 }
 
 // PopBack removes and returns the last element from the deque.
-// Returns an error if the deque is empty or type assertion fails.
+// Returns ErrEmptyQueue if the deque is empty, or ErrClosed if it is
+// empty and closed.
 func (d *Deque[T]) PopBack() (T, error) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	const fancName = "(*Deque[T]).PopBack"
 
-	if d.list.Len() == 0 {
-		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	if d.count == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, d.emptyErr())
 	}
 
-	elem := d.list.Back()
-	defer d.list.Remove(elem)
+	return d.popBack(), nil
+}
+
+// PopFrontWait removes and returns the first element from the deque,
+// blocking until an element is available.
+// Returns an error wrapping ctx.Err() if the context is done first, or
+// ErrClosed if the deque is closed and has no elements left.
+func (d *Deque[T]) PopFrontWait(ctx context.Context) (T, error) {
+	return d.popWait(ctx, "(*Deque[T]).PopFrontWait", d.popFront)
+}
 
-	val, ok := elem.Value.(T)
-	if !ok {
-		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrTypeAssertion)
+// PopBackWait removes and returns the last element from the deque,
+// blocking until an element is available.
+// Returns an error wrapping ctx.Err() if the context is done first, or
+// ErrClosed if the deque is closed and has no elements left.
+func (d *Deque[T]) PopBackWait(ctx context.Context) (T, error) {
+	return d.popWait(ctx, "(*Deque[T]).PopBackWait", d.popBack)
+}
+
+// Close marks the deque as closed and wakes every goroutine blocked
+// in PopFrontWait or PopBackWait. After Close every push fails with
+// ErrClosed, including pushes blocked on a full bounded deque, while
+// pops keep returning the remaining elements and then fail with
+// ErrClosed instead of ErrEmptyQueue.
+// Calling Close more than once has no effect
+func (d *Deque[T]) Close() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return
+	}
+
+	d.closed = true
+	if d.notEmpty != nil {
+		d.notEmpty.Broadcast()
+	}
+	d.signalNotFull()
+}
+
+// IsClosed reports whether Close has been called on the deque
+func (d *Deque[T]) IsClosed() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.closed
+}
+
+// Drain returns an iterator that removes and yields elements from the
+// front of the deque, blocking while it is empty. The iteration ends
+// once the deque is closed and every remaining element has been
+// yielded, or when the yield function returns false
+func (d *Deque[T]) Drain() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for {
+			val, err := d.PopFrontWait(context.Background())
+			if err != nil {
+				return
+			}
+			if !yield(val) {
+				return
+			}
+		}
 	}
-	return val, nil
 }
 
 // Front returns the first element from the deque without removing it.
-// Returns an error if the deque is empty or type assertion fails.
+// Returns an error if the deque is empty.
 func (d *Deque[T]) Front() (T, error) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
 	const fancName = "(*Deque[T]).Front"
 
-	if d.list.Len() == 0 {
+	if d.count == 0 {
 		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
 	}
 
-	elem := d.list.Front()
-
-	val, ok := elem.Value.(T)
-	if !ok {
-		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrTypeAssertion)
-	}
-	return val, nil
+	return d.buf[d.head], nil
 }
 
 // Back returns the last element from the deque without removing it.
-// Returns an error if the deque is empty or type assertion fails.
+// Returns an error if the deque is empty.
 func (d *Deque[T]) Back() (T, error) {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 	const fancName = "(*Deque[T]).Back"
 
-	if d.list.Len() == 0 {
+	if d.count == 0 {
 		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
 	}
 
-	elem := d.list.Back()
-
-	val, ok := elem.Value.(T)
-	if !ok {
-		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrTypeAssertion)
-	}
-	return val, nil
+	return d.buf[d.tail()], nil
 }
 
 // Clear removes all elements from the deque and returns the count
@@ -162,18 +327,16 @@ func (d *Deque[T]) Clear() int {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	if d.list.Len() == 0 {
-		return 0
-	}
-
-	cleared := 0
+	cleared := d.count
 
-	for e := d.list.Front(); e != nil; {
-		next := e.Next()
-		d.list.Remove(e)
-		e = next
-		cleared++
-	}
+	d.invalidateCursors()
+	d.buf = nil
+	d.head = 0
+	d.count = 0
+	d.holes = 0
+	d.dead = nil
+	d.version++
+	d.signalNotFull()
 
 	return cleared
 }
@@ -184,54 +347,118 @@ func (d *Deque[T]) ToArray() []T {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
 
-	if d.list.Len() == 0 {
-		return []T{}
-	}
-
-	arr := make([]T, 0, d.list.Len())
-
-	for e := d.list.Front(); e != nil; e = e.Next() {
-		val, ok := e.Value.(T)
-		if !ok {
-			break
-		}
-		arr = append(arr, val)
-	}
+	arr := make([]T, d.count)
+	d.copyTo(arr)
 
 	return arr
 }
 
 // Get retrieves the element at the specified index without removing it.
 // Returns the value and true if successful, zero value and false otherwise.
-// The operation is optimized by traversing from the closer end (front or back).
+// The operation is O(1), apart from compacting the deque once after
+// elements were removed through cursors.
 func (d *Deque[T]) Get(index int) (T, bool) {
-	d.mu.RLock()
+	d.rlockCompacted()
 	defer d.mu.RUnlock()
 
-	if index < 0 || index >= d.list.Len() || d.list.Len() == 0 {
+	if index < 0 || index >= d.count {
 		return zeroval[T](), false
 	}
 
-	var val T
-	var ok bool
+	return d.buf[d.at(index)], true
+}
 
-	if index < d.list.Len()/2 {
-		e := d.list.Front()
-		for i := 0; i < index; i++ {
-			e = e.Next()
-		}
-		val, ok = e.Value.(T)
+// Set replaces the element at the specified index with value.
+// Returns ErrIndexOutOfRange if the index is not in [0, Len()).
+// The operation is O(1), apart from compacting the deque once after
+// elements were removed through cursors.
+func (d *Deque[T]) Set(index int, value T) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).Set"
 
-	} else {
-		e := d.list.Back()
-		for i := d.list.Len() - 1; i > index; i-- {
-			e = e.Prev()
-		}
+	d.compact()
 
-		val, ok = e.Value.(T)
+	if index < 0 || index >= d.count {
+		return fmt.Errorf("%s: %w", fancName, ErrIndexOutOfRange)
 	}
 
-	return val, ok
+	d.buf[d.at(index)] = value
+	d.version++
+	return nil
+}
+
+// Swap exchanges the elements at indices i and j.
+// Returns ErrIndexOutOfRange if either index is not in [0, Len()).
+// The operation is O(1), apart from compacting the deque once after
+// elements were removed through cursors.
+func (d *Deque[T]) Swap(i, j int) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).Swap"
+
+	d.compact()
+
+	if i < 0 || i >= d.count || j < 0 || j >= d.count {
+		return fmt.Errorf("%s: %w", fancName, ErrIndexOutOfRange)
+	}
+
+	d.swapSlots(d.at(i), d.at(j))
+	d.version++
+	return nil
+}
+
+// Insert inserts one or more values before the element at the specified
+// index, in the same order they were provided, so that values[0] ends up
+// at index. An index equal to Len() appends the values to the back.
+// Returns ErrIndexOutOfRange if the index is not in [0, Len()].
+// Only the elements between index and the closer end of the deque are
+// shifted, so inserting near either end is cheap.
+// On a bounded deque OverflowBlock waits until all of the values fit and
+// OverflowReject fails with ErrFullQueue. OverflowEvict also fails with
+// ErrFullQueue, since an insertion in the middle has no opposite end to
+// evict from
+func (d *Deque[T]) Insert(index int, values ...T) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).Insert"
+
+	if d.closed {
+		return fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+	if index < 0 || index > d.count {
+		return fmt.Errorf("%s: %w", fancName, ErrIndexOutOfRange)
+	}
+
+	if err := d.admitInsert(fancName, values); err != nil {
+		return err
+	}
+
+	// admit may have waited for room, releasing the lock meanwhile
+	if index > d.count {
+		return fmt.Errorf("%s: %w", fancName, ErrIndexOutOfRange)
+	}
+
+	d.insertAt(index, values)
+	d.signalNotEmpty(len(values))
+
+	return nil
+}
+
+// RemoveAt removes and returns the element at the specified index.
+// Returns ErrIndexOutOfRange if the index is not in [0, Len()).
+// Only the elements between index and the closer end of the deque are
+// shifted, so removing near either end is cheap.
+func (d *Deque[T]) RemoveAt(index int) (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	const fancName = "(*Deque[T]).RemoveAt"
+
+	if index < 0 || index >= d.count {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrIndexOutOfRange)
+	}
+
+	return d.removeAt(index), nil
 }
 
 // Reverse reverses the order of elements in the deque in-place.
@@ -240,73 +467,242 @@ func (d *Deque[T]) Reverse() {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	if d.list.Len() <= 1 {
-		return
-	}
+	d.compact()
 
-	// In-place reversal without new list allocation
-	front := d.list.Front()
-	back := d.list.Back()
-	for i := 0; i < d.list.Len()/2; i++ {
-		front.Value, back.Value = back.Value, front.Value
-		front = front.Next()
-		back = back.Prev()
+	for i, j := 0, d.count-1; i < j; i, j = i+1, j-1 {
+		d.swapSlots(d.at(i), d.at(j))
 	}
+	d.version++
 }
 
 // Count returns the number of occurrences of `target` in the deque.
 // Uses the provided `equalFunc` to determine equality between elements
 func (d *Deque[T]) Count(target T, equalFunc func(T, T) bool) int {
-	d.mu.RLock()
+	d.rlockCompacted()
 	defer d.mu.RUnlock()
 
 	count := 0
-	for current := d.list.Front(); current != nil; current = current.Next() {
-		if equalFunc(current.Value.(T), target) {
+	for i := 0; i < d.count; i++ {
+		if equalFunc(d.buf[d.at(i)], target) {
 			count++
 		}
 	}
 	return count
 }
 
+// IndexFunc returns the index of the first element satisfying pred,
+// or -1 if there is none
+func (d *Deque[T]) IndexFunc(pred func(T) bool) int {
+	d.rlockCompacted()
+	defer d.mu.RUnlock()
+
+	for i := 0; i < d.count; i++ {
+		if pred(d.buf[d.at(i)]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// LastIndexFunc returns the index of the last element satisfying pred,
+// or -1 if there is none
+func (d *Deque[T]) LastIndexFunc(pred func(T) bool) int {
+	d.rlockCompacted()
+	defer d.mu.RUnlock()
+
+	for i := d.count - 1; i >= 0; i-- {
+		if pred(d.buf[d.at(i)]) {
+			return i
+		}
+	}
+	return -1
+}
+
+// ContainsFunc reports whether at least one element satisfies pred
+func (d *Deque[T]) ContainsFunc(pred func(T) bool) bool {
+	return d.IndexFunc(pred) >= 0
+}
+
+// RemoveFunc removes every element satisfying pred and returns the count
+// of elements that were removed. The remaining elements keep their order.
+// The deque is compacted in a single pass, so the operation is O(n)
+// however many elements match
+func (d *Deque[T]) RemoveFunc(pred func(T) bool) int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.compact()
+
+	kept := 0
+	for i := 0; i < d.count; i++ {
+		slot := d.at(i)
+		if pred(d.buf[slot]) {
+			d.untag(slot)
+			continue
+		}
+		if kept != i {
+			d.move(d.at(kept), slot)
+		}
+		kept++
+	}
+
+	removed := d.count - kept
+	if removed == 0 {
+		return 0
+	}
+
+	for i := kept; i < d.count; i++ {
+		d.buf[d.at(i)] = zeroval[T]() // allow GC of the removed values
+	}
+	d.count = kept
+	d.version++
+	d.shrink()
+	d.signalNotFull()
+
+	return removed
+}
+
+// RemoveFirstFunc removes the first element satisfying pred and returns it.
+// Returns the value and true if an element was removed, zero value and
+// false otherwise
+func (d *Deque[T]) RemoveFirstFunc(pred func(T) bool) (T, bool) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.compact()
+
+	for i := 0; i < d.count; i++ {
+		if pred(d.buf[d.at(i)]) {
+			return d.removeAt(i), true
+		}
+	}
+	return zeroval[T](), false
+}
+
 // Iterator returns a forward iterator (yields elements from front to back).
+// It iterates over a snapshot of the deque, as Iter(IterSnapshot) does,
+// so the loop body may access and modify the deque.
 // The iterator terminates if the yield function returns false
 func (d *Deque[T]) Iterator() iter.Seq2[int, T] {
-	return func(yield func(int, T) bool) {
-		d.mu.Lock()
-		defer d.mu.Unlock()
+	return d.Iter(IterSnapshot)
+}
 
-		if d.list.Len() == 0 {
+// Iter returns a forward iterator (yields elements from front to back)
+// that deals with concurrent modifications according to mode.
+// The iterator terminates if the yield function returns false
+func (d *Deque[T]) Iter(mode IterMode) iter.Seq2[int, T] {
+	switch mode {
+	case IterLocked:
+		return d.lockedIter()
+	default:
+		return d.snapshotIter()
+	}
+}
+
+// IterChecked returns a fail-fast forward iterator along with a function
+// that reports how the iteration ended. The iterator takes the read lock
+// only to read each element, so the loop body may access the deque, and
+// stops as soon as it detects that the deque was modified since the loop
+// started, whether by the loop body or by another goroutine. The function
+// then returns an error wrapping ErrConcurrentModification; it returns nil
+// if the loop ran to the end or was stopped by the loop body.
+// The iterator is single-use: ranging over it again yields nothing. Call
+// IterChecked again for each loop, and call the function once the loop
+// has ended
+func (d *Deque[T]) IterChecked() (iter.Seq2[int, T], func() error) {
+	var (
+		used atomic.Bool
+		err  error
+	)
+
+	seq := func(yield func(int, T) bool) {
+		if used.Swap(true) {
 			return
 		}
+		err = d.failFast(yield)
+	}
+	return seq, func() error { return err }
+}
 
-		index := 0
-		for current := d.list.Front(); current != nil; current = current.Next() {
-			if !yield(index, current.Value.(T)) {
+// DescendingIterator returns a reverse iterator (yields elements from back to front).
+// The index is the iteration ordinal: 0 for the back element, 1 for the
+// one before it, and so on. Use Backward to get positions in the deque.
+// It iterates over a snapshot of the deque, so the loop body may access
+// and modify the deque.
+// The iterator terminates if the yield function returns false
+func (d *Deque[T]) DescendingIterator() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		values := d.ToArray()
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(len(values)-1-i, values[i]) {
 				return
 			}
-			index++
 		}
 	}
 }
 
 // DescendingeIterator returns a reverse iterator (yields elements from back to front).
-// The iterator terminates if the yield function returns false.
+//
+// Deprecated: use DescendingIterator, which this is an alias of
 func (d *Deque[T]) DescendingeIterator() iter.Seq2[int, T] {
+	return d.DescendingIterator()
+}
+
+// Backward returns a reverse iterator (yields elements from back to front)
+// whose index is the position of the element in the deque, the same
+// index Get takes: Len()-1 for the back element down to 0 for the front.
+// It iterates over a snapshot of the deque, so the loop body may access
+// and modify the deque.
+// The iterator terminates if the yield function returns false
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
 	return func(yield func(int, T) bool) {
-		d.mu.Lock()
-		defer d.mu.Unlock()
+		values := d.ToArray()
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(i, values[i]) {
+				return
+			}
+		}
+	}
+}
 
-		if d.list.Len() == 0 {
-			return
+// Values returns an iterator over the values of the deque from front to
+// back, without their indices. It iterates over a snapshot of the deque,
+// so the loop body may access and modify the deque
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range d.ToArray() {
+			if !yield(v) {
+				return
+			}
 		}
+	}
+}
 
-		index := 0
-		for current := d.list.Front(); current != nil; current = current.Next() {
-			if !yield(index, current.Value.(T)) {
+// Range returns an iterator over the elements at positions [from, to),
+// from front to back, yielding each with its position in the deque.
+// The bounds are clamped to [0, Len()] when the iteration starts, and only
+// the elements in the range are copied into the snapshot iterated over
+func (d *Deque[T]) Range(from, to int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		from, values := d.snapshotRange(from, to)
+		for i, v := range values {
+			if !yield(from+i, v) {
+				return
+			}
+		}
+	}
+}
+
+// RangeBackward returns an iterator over the elements at positions
+// [from, to), from back to front, yielding each with its position in the
+// deque. The bounds are clamped as in Range
+func (d *Deque[T]) RangeBackward(from, to int) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		from, values := d.snapshotRange(from, to)
+		for i := len(values) - 1; i >= 0; i-- {
+			if !yield(from+i, values[i]) {
 				return
 			}
-			index++
 		}
 	}
 }
@@ -320,10 +716,11 @@ func (d *Deque[T]) Rotate(n int) {
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	length := d.list.Len()
-	if d.list.Len() <= 1 || n == 0 {
+	length := d.count
+	if length <= 1 || n == 0 {
 		return
 	}
+	d.compact()
 
 	// Normalize n to be within [0, length)
 	n = n % length
@@ -331,6 +728,8 @@ func (d *Deque[T]) Rotate(n int) {
 		n += length
 	}
 
+	d.version++
+
 	// Optimize by rotating in the most efficient direction
 	if n <= length/2 {
 		d.rotateRight(n)
@@ -345,8 +744,21 @@ func (d *Deque[T]) Rotate(n int) {
 // Example: [1, 2, 3, 4] rotated right by 1 becomes [4, 1, 2, 3].
 // Assumes n is positive and caller holds the lock
 func (d *Deque[T]) rotateRight(n int) {
+	if d.count == 0 {
+		return
+	}
+
+	// A full buffer has no free slots, so moving the head is enough
+	if d.count == len(d.buf) {
+		d.head = (d.head - n) & (len(d.buf) - 1)
+		return
+	}
+
 	for i := 0; i < n; i++ {
-		d.list.MoveToFront(d.list.Back())
+		tail := d.at(d.count - 1)
+		d.head = d.prev(d.head)
+		d.move(d.head, tail)
+		d.buf[tail] = zeroval[T]()
 	}
 }
 
@@ -355,7 +767,515 @@ func (d *Deque[T]) rotateRight(n int) {
 // Example: [1, 2, 3, 4] rotated left by 1 becomes [2, 3, 4, 1].
 // Assumes n is positive and caller holds the lock
 func (d *Deque[T]) rotateLeft(n int) {
+	if d.count == 0 {
+		return
+	}
+
+	// A full buffer has no free slots, so moving the head is enough
+	if d.count == len(d.buf) {
+		d.head = (d.head + n) & (len(d.buf) - 1)
+		return
+	}
+
 	for i := n; i > 0; i-- {
-		d.list.MoveToBack(d.list.Front())
+		d.move(d.at(d.count), d.head)
+		d.buf[d.head] = zeroval[T]()
+		d.head = d.next(d.head)
+	}
+}
+
+// snapshotIter iterates over a copy of the elements taken under the
+// read lock
+func (d *Deque[T]) snapshotIter() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i, v := range d.ToArray() {
+			if !yield(i, v) {
+				return
+			}
+		}
+	}
+}
+
+// snapshotRange clamps [from, to) to the current elements and returns the
+// clamped start along with a copy of the elements in the range
+func (d *Deque[T]) snapshotRange(from, to int) (int, []T) {
+	d.rlockCompacted()
+	defer d.mu.RUnlock()
+
+	from, to = max(from, 0), min(to, d.count)
+	if from >= to {
+		return from, nil
+	}
+
+	values := make([]T, to-from)
+	for i := range values {
+		values[i] = d.buf[d.at(from+i)]
+	}
+	return from, values
+}
+
+// lockedIter iterates over the elements while holding the read lock
+func (d *Deque[T]) lockedIter() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		d.rlockCompacted()
+		defer d.mu.RUnlock()
+
+		for i := 0; i < d.count; i++ {
+			if !yield(i, d.buf[d.at(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// failFast yields each element, read under the read lock, and stops with
+// an error if the version of the deque changed since the iteration started
+func (d *Deque[T]) failFast(yield func(int, T) bool) error {
+	const fancName = "(*Deque[T]).IterChecked"
+
+	d.mu.RLock()
+	version := d.version
+	d.mu.RUnlock()
+
+	for i := 0; ; i++ {
+		d.rlockCompacted()
+		modified := d.version != version
+		done := i >= d.count
+		var v T
+		if !modified && !done {
+			v = d.buf[d.at(i)]
+		}
+		d.mu.RUnlock()
+
+		if modified {
+			return fmt.Errorf("%s: %w", fancName, ErrConcurrentModification)
+		}
+		if done || !yield(i, v) {
+			return nil
+		}
+	}
+}
+
+// popFront removes and returns the front element.
+// Assumes the deque is not empty and caller holds the write lock
+func (d *Deque[T]) popFront() T {
+	val := d.buf[d.head]
+	d.untag(d.head)
+	d.buf[d.head] = zeroval[T]() // allow GC of the removed value
+	d.head = d.next(d.head)
+	d.count--
+	d.trimHoles()
+	d.version++
+	d.shrink()
+	d.signalNotFull()
+
+	return val
+}
+
+// popBack removes and returns the back element.
+// Assumes the deque is not empty and caller holds the write lock
+func (d *Deque[T]) popBack() T {
+	tail := d.tail()
+	val := d.buf[tail]
+	d.untag(tail)
+	d.buf[tail] = zeroval[T]() // allow GC of the removed value
+	d.count--
+	d.trimHoles()
+	d.version++
+	d.shrink()
+	d.signalNotFull()
+
+	return val
+}
+
+// pushFront adds the values to the front of the deque so that values[0]
+// ends up first. Assumes there is room for the values and caller holds
+// the write lock
+func (d *Deque[T]) pushFront(values []T) {
+	d.reserve(len(values))
+	for i := len(values) - 1; i >= 0; i-- {
+		d.head = d.prev(d.head)
+		d.buf[d.head] = values[i]
+		d.count++
+	}
+	d.version++
+}
+
+// pushBack adds the values to the back of the deque in order.
+// Assumes there is room for the values and caller holds the write lock
+func (d *Deque[T]) pushBack(values []T) {
+	d.reserve(len(values))
+	for _, v := range values {
+		d.buf[d.at(d.count+d.holes)] = v
+		d.count++
+	}
+	d.version++
+}
+
+// insertAt opens a gap of len(values) slots before the logical position
+// index by shifting the elements on the side closer to an end, and copies
+// the values into it. Assumes there is room for the values and caller
+// holds the write lock
+func (d *Deque[T]) insertAt(index int, values []T) {
+	n := len(values)
+	if n == 0 {
+		return
+	}
+	d.compact()
+	d.reserve(n)
+
+	if index < d.count-index {
+		// Move the head back and slide the elements before index into
+		// the freed slots
+		d.head = (d.head - n) & (len(d.buf) - 1)
+		for i := 0; i < index; i++ {
+			d.move(d.at(i), d.at(i+n))
+		}
+	} else {
+		for i := d.count - 1; i >= index; i-- {
+			d.move(d.at(i+n), d.at(i))
+		}
+	}
+
+	for i, v := range values {
+		d.buf[d.at(index+i)] = v
+	}
+	d.count += n
+	d.version++
+}
+
+// removeAt removes and returns the element at the logical position index
+// by shifting the elements on the side closer to an end over it.
+// Assumes index is in range and caller holds the write lock
+func (d *Deque[T]) removeAt(index int) T {
+	d.compact()
+	val := d.buf[d.at(index)]
+	d.untag(d.at(index))
+
+	if index < d.count/2 {
+		for i := index; i > 0; i-- {
+			d.move(d.at(i), d.at(i-1))
+		}
+		d.buf[d.head] = zeroval[T]() // allow GC of the removed value
+		d.head = d.next(d.head)
+	} else {
+		for i := index; i < d.count-1; i++ {
+			d.move(d.at(i), d.at(i+1))
+		}
+		d.buf[d.at(d.count-1)] = zeroval[T]() // allow GC of the removed value
+	}
+
+	d.count--
+	d.version++
+	d.shrink()
+	d.signalNotFull()
+
+	return val
+}
+
+// admitInsert checks that the values can be inserted in the middle of
+// the deque, waiting for room under OverflowBlock. The lock may be
+// released while waiting, so the caller must re-validate positions
+// afterwards. Caller must hold the write lock
+func (d *Deque[T]) admitInsert(fancName string, values []T) error {
+	if d.closed {
+		return fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+	if d.policy == OverflowEvict && d.capacity > 0 && d.count+len(values) > d.capacity {
+		return fmt.Errorf("%s: %w", fancName, ErrFullQueue)
+	}
+
+	_, err := d.admit(fancName, values, true)
+	return err
+}
+
+// emptyErr returns the error reported by pops on an empty deque.
+// Caller must hold the lock
+func (d *Deque[T]) emptyErr() error {
+	if d.closed {
+		return ErrClosed
+	}
+	return ErrEmptyQueue
+}
+
+// admit applies the overflow policy to a batch of values about to be
+// pushed to the back (or the front if back is false) and returns the
+// values that should actually be pushed.
+// Caller must hold the write lock
+func (d *Deque[T]) admit(fancName string, values []T, back bool) ([]T, error) {
+	if d.capacity == 0 || d.count+len(values) <= d.capacity {
+		return values, nil
+	}
+
+	switch d.policy {
+	case OverflowEvict:
+		if len(values) > d.capacity {
+			if back {
+				values = values[len(values)-d.capacity:]
+			} else {
+				values = values[:d.capacity]
+			}
+		}
+
+		for d.count+len(values) > d.capacity {
+			if back {
+				d.popFront()
+			} else {
+				d.popBack()
+			}
+		}
+		return values, nil
+
+	case OverflowBlock:
+		if len(values) > d.capacity {
+			return nil, fmt.Errorf("%s: %w", fancName, ErrFullQueue)
+		}
+
+		cond := d.notFullCond()
+		for !d.closed && d.count+len(values) > d.capacity {
+			cond.Wait()
+		}
+
+		// Close may have run between the wakeup that made room and this
+		// goroutine reacquiring the lock
+		if d.closed {
+			return nil, fmt.Errorf("%s: %w", fancName, ErrClosed)
+		}
+		return values, nil
+
+	default:
+		return nil, fmt.Errorf("%s: %w", fancName, ErrFullQueue)
+	}
+}
+
+// popWait parks the caller on the notEmpty condition until pop can be
+// applied, the context is done or the deque is closed
+func (d *Deque[T]) popWait(ctx context.Context, fancName string, pop func() T) (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cond := d.notEmptyCond()
+
+	// sync.Cond knows nothing about contexts, so wake every waiter
+	// when ctx is done and let each of them re-check its own context
+	stop := context.AfterFunc(ctx, func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		cond.Broadcast()
+	})
+	defer stop()
+
+	for d.count == 0 {
+		if d.closed {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrClosed)
+		}
+		if err := ctx.Err(); err != nil {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, err)
+		}
+		cond.Wait()
+	}
+
+	return pop(), nil
+}
+
+// notEmptyCond returns the condition waiters park on, creating it on
+// first use. Caller must hold the write lock
+func (d *Deque[T]) notEmptyCond() *sync.Cond {
+	if d.notEmpty == nil {
+		d.notEmpty = sync.NewCond(&d.mu)
+	}
+	return d.notEmpty
+}
+
+// notFullCond returns the condition blocked pushers park on, creating
+// it on first use. Caller must hold the write lock
+func (d *Deque[T]) notFullCond() *sync.Cond {
+	if d.notFull == nil {
+		d.notFull = sync.NewCond(&d.mu)
+	}
+	return d.notFull
+}
+
+// signalNotFull wakes every goroutine waiting for free room, since
+// each of them may need a different amount of it.
+// Caller must hold the write lock
+func (d *Deque[T]) signalNotFull() {
+	if d.notFull != nil {
+		d.notFull.Broadcast()
+	}
+}
+
+// signalNotEmpty wakes up to n goroutines waiting for an element.
+// Caller must hold the write lock
+func (d *Deque[T]) signalNotEmpty(n int) {
+	if d.notEmpty == nil {
+		return
+	}
+
+	for i := 0; i < n; i++ {
+		d.notEmpty.Signal()
+	}
+}
+
+// at maps a logical position (0 is the front) to an index in buf.
+// Assumes buf is not empty, the deque has no holes and caller holds the
+// lock
+func (d *Deque[T]) at(i int) int {
+	return (d.head + i) & (len(d.buf) - 1)
+}
+
+// tail returns the buffer index of the back element.
+// Assumes the deque is not empty and caller holds the lock
+func (d *Deque[T]) tail() int {
+	return (d.head + d.count + d.holes - 1) & (len(d.buf) - 1)
+}
+
+// nextLive returns the buffer index of the element following the one in
+// slot i, skipping holes. Caller must hold the lock
+func (d *Deque[T]) nextLive(i int) int {
+	i = d.next(i)
+	for d.holes > 0 && d.dead[i] {
+		i = d.next(i)
+	}
+	return i
+}
+
+// prevLive returns the buffer index of the element preceding the one in
+// slot i, skipping holes. Caller must hold the lock
+func (d *Deque[T]) prevLive(i int) int {
+	i = d.prev(i)
+	for d.holes > 0 && d.dead[i] {
+		i = d.prev(i)
+	}
+	return i
+}
+
+// next returns the buffer index following i, wrapping around
+func (d *Deque[T]) next(i int) int {
+	return (i + 1) & (len(d.buf) - 1)
+}
+
+// prev returns the buffer index preceding i, wrapping around
+func (d *Deque[T]) prev(i int) int {
+	return (i - 1) & (len(d.buf) - 1)
+}
+
+// copyTo copies the elements front to back into dst, which must have
+// room for at least count elements. Caller must hold the lock
+func (d *Deque[T]) copyTo(dst []T) {
+	if d.holes == 0 {
+		copyRing(dst, d.buf, d.head, d.count)
+		return
+	}
+
+	for i, slot := 0, d.head; i < d.count; i, slot = i+1, d.nextLive(slot) {
+		dst[i] = d.buf[slot]
+	}
+}
+
+// copyRing copies count elements of the ring buffer src, starting at
+// index head, into dst
+func copyRing[E any](dst, src []E, head, count int) {
+	if count == 0 {
+		return
+	}
+
+	if head+count <= len(src) {
+		copy(dst, src[head:head+count])
+		return
+	}
+
+	n := copy(dst, src[head:])
+	copy(dst[n:], src[:count-n])
+}
+
+// reserve makes sure the buffer has room for n more elements after the
+// back one, growing it to the next power of two if needed. A buffer
+// where holes take at least a quarter of the slots is compacted instead
+// of grown. Caller must hold the write lock
+func (d *Deque[T]) reserve(n int) {
+	if d.count+d.holes+n <= len(d.buf) {
+		return
+	}
+
+	size := max(len(d.buf), minCapacity)
+	for size < d.count+n || (size == len(d.buf) && d.holes < size/4) {
+		size <<= 1
+	}
+
+	d.resize(size)
+}
+
+// shrink halves the buffer while it is at most a quarter full, so a
+// deque that once held many elements does not keep the memory forever.
+// Caller must hold the write lock
+func (d *Deque[T]) shrink() {
+	size := len(d.buf)
+	for size > minCapacity && d.count <= size/4 {
+		size /= 2
+	}
+
+	if size != len(d.buf) {
+		d.resize(size)
+	}
+}
+
+// resize moves the elements into a new buffer of the given size,
+// which must be a power of two not smaller than count, dropping the holes
+func (d *Deque[T]) resize(size int) {
+	buf := make([]T, size)
+	d.copyTo(buf)
+
+	if d.cursors != nil {
+		cursors := make([]*Cursor[T], size)
+		for i, slot := 0, d.head; i < d.count; i, slot = i+1, d.nextLive(slot) {
+			if c := d.cursors[slot]; c != nil {
+				c.slot = i
+				cursors[i] = c
+			}
+		}
+		d.cursors = cursors
+	}
+
+	d.buf = buf
+	d.head = 0
+	d.holes = 0
+	d.dead = nil
+}
+
+// compact drops the holes, so that logical positions map directly to
+// buffer slots again. Caller must hold the write lock
+func (d *Deque[T]) compact() {
+	if d.holes > 0 {
+		d.resize(len(d.buf))
+	}
+}
+
+// rlockCompacted takes the read lock once the deque has no holes,
+// compacting it under the write lock first if needed, for readers that
+// address elements by position
+func (d *Deque[T]) rlockCompacted() {
+	d.mu.RLock()
+	for d.holes > 0 {
+		d.mu.RUnlock()
+		d.mu.Lock()
+		d.compact()
+		d.mu.Unlock()
+		d.mu.RLock()
+	}
+}
+
+// trimHoles drops the holes left at either end by a pop, so that the
+// front and back slots always hold elements.
+// Caller must hold the write lock
+func (d *Deque[T]) trimHoles() {
+	for d.holes > 0 && d.dead[d.head] {
+		d.dead[d.head] = false
+		d.head = d.next(d.head)
+		d.holes--
+	}
+	for d.holes > 0 && d.dead[d.tail()] {
+		d.dead[d.tail()] = false
+		d.holes--
 	}
 }
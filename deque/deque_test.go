@@ -1,7 +1,11 @@
 package deque
 
 import (
+	"context"
 	"fmt"
+	"maps"
+	"slices"
+	"sync"
 	"testing"
 	"time"
 
@@ -11,7 +15,6 @@ import (
 func TestNew(t *testing.T) {
 	d := New[int]()
 	assert.NotNil(t, d)
-	assert.NotNil(t, d.list)
 	assert.Equal(t, 0, d.Len())
 	assert.True(t, d.IsEmpty())
 }
@@ -275,6 +278,247 @@ func TestGet(t *testing.T) {
 	}
 }
 
+func TestSet(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 2, 3))
+
+	assert.NoError(t, d.Set(1, 20))
+	assert.Equal(t, []int{1, 20, 3}, d.ToArray())
+
+	assert.ErrorIs(t, d.Set(-1, 0), ErrIndexOutOfRange)
+	assert.ErrorIs(t, d.Set(3, 0), ErrIndexOutOfRange)
+}
+
+func TestSwap(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 2, 3))
+
+	assert.NoError(t, d.Swap(0, 2))
+	assert.Equal(t, []int{3, 2, 1}, d.ToArray())
+
+	assert.NoError(t, d.Swap(1, 1))
+	assert.Equal(t, []int{3, 2, 1}, d.ToArray())
+
+	assert.ErrorIs(t, d.Swap(0, 3), ErrIndexOutOfRange)
+	assert.ErrorIs(t, d.Swap(-1, 0), ErrIndexOutOfRange)
+}
+
+func TestInsert(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    int
+		values   []int
+		expected []int
+	}{
+		{"front", 0, []int{8, 9}, []int{8, 9, 1, 2, 3, 4, 5}},
+		{"near front", 1, []int{8, 9}, []int{1, 8, 9, 2, 3, 4, 5}},
+		{"middle", 2, []int{8}, []int{1, 2, 8, 3, 4, 5}},
+		{"near back", 4, []int{8, 9}, []int{1, 2, 3, 4, 8, 9, 5}},
+		{"back", 5, []int{8, 9}, []int{1, 2, 3, 4, 5, 8, 9}},
+		{"no values", 2, nil, []int{1, 2, 3, 4, 5}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New[int]()
+			assert.NoError(t, d.PushBack(1, 2, 3, 4, 5))
+
+			assert.NoError(t, d.Insert(tt.index, tt.values...))
+			assert.Equal(t, tt.expected, d.ToArray())
+		})
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		d := New[int]()
+		assert.ErrorIs(t, d.Insert(1, 1), ErrIndexOutOfRange)
+		assert.ErrorIs(t, d.Insert(-1, 1), ErrIndexOutOfRange)
+		assert.NoError(t, d.Insert(0, 1))
+		assert.Equal(t, []int{1}, d.ToArray())
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		d := New[int]()
+		d.Close()
+		assert.ErrorIs(t, d.Insert(0, 1), ErrClosed)
+	})
+
+	t.Run("bounded", func(t *testing.T) {
+		d := NewBounded[int](3, OverflowReject)
+		assert.NoError(t, d.PushBack(1, 3))
+		assert.ErrorIs(t, d.Insert(1, 2, 2), ErrFullQueue)
+		assert.NoError(t, d.Insert(1, 2))
+		assert.Equal(t, []int{1, 2, 3}, d.ToArray())
+
+		d = NewBounded[int](2, OverflowEvict)
+		assert.NoError(t, d.PushBack(1, 3))
+		assert.ErrorIs(t, d.Insert(1, 2), ErrFullQueue)
+		assert.Equal(t, []int{1, 3}, d.ToArray())
+	})
+
+	t.Run("bounded block", func(t *testing.T) {
+		d := NewBounded[int](2, OverflowBlock)
+		assert.NoError(t, d.PushBack(1, 3))
+
+		done := make(chan error)
+		go func() {
+			done <- d.Insert(1, 2)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		_, err := d.PopBack()
+		assert.NoError(t, err)
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+			assert.Equal(t, []int{1, 2}, d.ToArray())
+		case <-time.After(time.Second):
+			t.Fatal("Insert did not return after room was made")
+		}
+	})
+}
+
+func TestRemoveAt(t *testing.T) {
+	for index := 0; index < 5; index++ {
+		t.Run(fmt.Sprintf("index %d", index), func(t *testing.T) {
+			d := New[int]()
+			assert.NoError(t, d.PushBack(0, 1, 2, 3, 4))
+
+			val, err := d.RemoveAt(index)
+			assert.NoError(t, err)
+			assert.Equal(t, index, val)
+
+			expected := []int{}
+			for i := 0; i < 5; i++ {
+				if i != index {
+					expected = append(expected, i)
+				}
+			}
+			assert.Equal(t, expected, d.ToArray())
+		})
+	}
+
+	t.Run("out of range", func(t *testing.T) {
+		d := New[int]()
+		_, err := d.RemoveAt(0)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+
+		assert.NoError(t, d.PushBack(1))
+		_, err = d.RemoveAt(1)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+		_, err = d.RemoveAt(-1)
+		assert.ErrorIs(t, err, ErrIndexOutOfRange)
+	})
+}
+
+func TestInsertRemoveAt_WrapAround(t *testing.T) {
+	d := New[int]()
+	var ref []int
+
+	// Push from both ends so that the elements wrap around the buffer,
+	// then edit in the middle and compare against a plain slice
+	for i := 0; i < 40; i++ {
+		if i%2 == 0 {
+			assert.NoError(t, d.PushFront(i))
+			ref = append([]int{i}, ref...)
+		} else {
+			assert.NoError(t, d.PushBack(i))
+			ref = append(ref, i)
+		}
+	}
+
+	for i := 0; i < 200; i++ {
+		index := (i * 7) % (len(ref) + 1)
+		if i%3 == 0 && len(ref) > 0 {
+			index %= len(ref)
+			val, err := d.RemoveAt(index)
+			assert.NoError(t, err)
+			assert.Equal(t, ref[index], val)
+			ref = append(ref[:index], ref[index+1:]...)
+		} else {
+			assert.NoError(t, d.Insert(index, -i, -i-1))
+			ref = append(ref[:index], append([]int{-i, -i - 1}, ref[index:]...)...)
+		}
+		if !assert.Equal(t, ref, d.ToArray()) {
+			return
+		}
+	}
+}
+
+func TestIndexFunc(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 2, 3, 2, 1))
+
+	isTwo := func(v int) bool { return v == 2 }
+	isTen := func(v int) bool { return v == 10 }
+
+	assert.Equal(t, 1, d.IndexFunc(isTwo))
+	assert.Equal(t, 3, d.LastIndexFunc(isTwo))
+	assert.True(t, d.ContainsFunc(isTwo))
+
+	assert.Equal(t, -1, d.IndexFunc(isTen))
+	assert.Equal(t, -1, d.LastIndexFunc(isTen))
+	assert.False(t, d.ContainsFunc(isTen))
+
+	empty := New[int]()
+	assert.Equal(t, -1, empty.IndexFunc(isTwo))
+	assert.Equal(t, -1, empty.LastIndexFunc(isTwo))
+	assert.False(t, empty.ContainsFunc(isTwo))
+}
+
+func TestRemoveFunc(t *testing.T) {
+	isEven := func(v int) bool { return v%2 == 0 }
+
+	tests := []struct {
+		name     string
+		input    []int
+		removed  int
+		expected []int
+	}{
+		{"mixed", []int{1, 2, 3, 4, 5, 6}, 3, []int{1, 3, 5}},
+		{"no matches", []int{1, 3, 5}, 0, []int{1, 3, 5}},
+		{"all match", []int{2, 4, 6}, 3, []int{}},
+		{"empty", []int{}, 0, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := New[int]()
+			assert.NoError(t, d.PushBack(tt.input...))
+
+			assert.Equal(t, tt.removed, d.RemoveFunc(isEven))
+			assert.Equal(t, tt.expected, d.ToArray())
+			assert.Equal(t, len(tt.expected), d.Len())
+		})
+	}
+
+	t.Run("wrapped buffer shrinks", func(t *testing.T) {
+		d := New[int]()
+		for i := 0; i < 1000; i++ {
+			assert.NoError(t, d.PushFront(i))
+		}
+
+		assert.Equal(t, 998, d.RemoveFunc(func(v int) bool { return v > 1 }))
+		assert.Equal(t, []int{1, 0}, d.ToArray())
+		assert.Equal(t, minCapacity, len(d.buf))
+	})
+}
+
+func TestRemoveFirstFunc(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 2, 3, 2, 1))
+
+	val, ok := d.RemoveFirstFunc(func(v int) bool { return v == 2 })
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+	assert.Equal(t, []int{1, 3, 2, 1}, d.ToArray())
+
+	val, ok = d.RemoveFirstFunc(func(v int) bool { return v == 10 })
+	assert.False(t, ok)
+	assert.Equal(t, 0, val)
+	assert.Equal(t, 4, d.Len())
+}
+
 func TestConcurrency(t *testing.T) {
 	d := New[int]()
 	const numWorkers = 100
@@ -354,9 +598,7 @@ func TestDeque_Reverse(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := New[int]()
-			for _, v := range tt.input {
-				d.list.PushBack(v)
-			}
+			d.PushBack(tt.input...)
 
 			d.Reverse()
 			assert.Equal(t, tt.expected, d.ToArray())
@@ -395,9 +637,7 @@ func TestDeque_Iterator(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := New[int]()
-			for _, v := range tt.input {
-				d.list.PushBack(v)
-			}
+			d.PushBack(tt.input...)
 
 			var result = []pair{}
 			for i, v := range d.Iterator() {
@@ -408,6 +648,180 @@ func TestDeque_Iterator(t *testing.T) {
 	}
 }
 
+func TestDeque_Iter(t *testing.T) {
+	modes := []struct {
+		name string
+		mode IterMode
+	}{
+		{"snapshot", IterSnapshot},
+		{"locked", IterLocked},
+	}
+
+	for _, m := range modes {
+		t.Run(m.name, func(t *testing.T) {
+			d := New[int]()
+			assert.NoError(t, d.PushFront(3, 4))
+			assert.NoError(t, d.PushBack(5))
+			assert.NoError(t, d.PushFront(1, 2))
+
+			var indices, values []int
+			for i, v := range d.Iter(m.mode) {
+				indices = append(indices, i)
+				values = append(values, v)
+			}
+			assert.Equal(t, []int{0, 1, 2, 3, 4}, indices)
+			assert.Equal(t, []int{1, 2, 3, 4, 5}, values)
+
+			values = values[:0]
+			for _, v := range d.Iter(m.mode) {
+				if v == 3 {
+					break
+				}
+				values = append(values, v)
+			}
+			assert.Equal(t, []int{1, 2}, values)
+		})
+	}
+}
+
+func TestDeque_IterSnapshot_Modify(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 2, 3))
+
+	// The loop body may modify the deque without deadlocking, and the
+	// loop keeps seeing the elements as they were when it started
+	var result []int
+	for _, v := range d.Iterator() {
+		result = append(result, v)
+		assert.NoError(t, d.PushBack(v*10))
+	}
+	assert.Equal(t, []int{1, 2, 3}, result)
+	assert.Equal(t, []int{1, 2, 3, 10, 20, 30}, d.ToArray())
+}
+
+func TestDeque_IterLocked_Readers(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 2, 3))
+
+	// Other readers are not blocked while the loop holds the read lock
+	for range d.Iter(IterLocked) {
+		done := make(chan int)
+		go func() { done <- d.Len() }()
+
+		select {
+		case n := <-done:
+			assert.Equal(t, 3, n)
+		case <-time.After(time.Second):
+			t.Fatal("reader blocked by locked iteration")
+		}
+	}
+}
+
+func TestDeque_IterChecked(t *testing.T) {
+	t.Run("complete run", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		seq, errFn := d.IterChecked()
+		var indices, values []int
+		for i, v := range seq {
+			indices = append(indices, i)
+			values = append(values, v)
+		}
+		assert.Equal(t, []int{0, 1, 2}, indices)
+		assert.Equal(t, []int{1, 2, 3}, values)
+		assert.NoError(t, errFn())
+	})
+
+	t.Run("stopped by loop body", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		seq, errFn := d.IterChecked()
+		for range seq {
+			break
+		}
+		assert.NoError(t, errFn())
+	})
+
+	t.Run("modified by loop body", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		seq, errFn := d.IterChecked()
+		var result []int
+		for _, v := range seq {
+			result = append(result, v)
+			assert.NoError(t, d.Set(2, 30))
+		}
+		assert.Equal(t, []int{1}, result)
+
+		err := errFn()
+		assert.ErrorIs(t, err, ErrConcurrentModification)
+		assert.EqualError(t, err, "(*Deque[T]).IterChecked: "+ErrConcurrentModification.Error())
+	})
+
+	t.Run("modified by another goroutine", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		seq, errFn := d.IterChecked()
+		var result []int
+		for _, v := range seq {
+			result = append(result, v)
+			done := make(chan struct{})
+			go func() {
+				d.PopBack()
+				close(done)
+			}()
+			<-done
+		}
+		assert.Equal(t, []int{1}, result)
+		assert.ErrorIs(t, errFn(), ErrConcurrentModification)
+	})
+
+	t.Run("read-only operations are allowed", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		seq, errFn := d.IterChecked()
+		var result []int
+		for i, v := range seq {
+			d.Get(i)
+			d.ToArray()
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{1, 2, 3}, result)
+		assert.NoError(t, errFn())
+	})
+
+	t.Run("single use", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		seq, errFn := d.IterChecked()
+
+		// Only one of the concurrent runs yields, and they do not race on
+		// the outcome
+		counts := make(chan int, 2)
+		for range 2 {
+			go func() {
+				n := 0
+				for range seq {
+					n++
+				}
+				counts <- n
+			}()
+		}
+		assert.Equal(t, 3, <-counts+<-counts)
+		assert.NoError(t, errFn())
+
+		for range seq {
+			t.Fatal("a used iterator yielded again")
+		}
+	})
+}
+
 func TestDeque_DescendingIterator(t *testing.T) {
 	type pair struct {
 		i int
@@ -415,42 +829,158 @@ func TestDeque_DescendingIterator(t *testing.T) {
 	}
 
 	tests := []struct {
-		name     string
-		input    []int
-		expected []pair
+		name       string
+		input      []int
+		descending []pair
+		backward   []pair
 	}{
 		{
-			name:     "normal reverse iteration",
-			input:    []int{1, 2, 3},
-			expected: []pair{{0, 1}, {1, 2}, {2, 3}},
+			name:       "normal reverse iteration",
+			input:      []int{1, 2, 3},
+			descending: []pair{{0, 3}, {1, 2}, {2, 1}},
+			backward:   []pair{{2, 3}, {1, 2}, {0, 1}},
 		},
 		{
-			name:     "empty deque",
-			input:    []int{},
-			expected: []pair{},
+			name:       "empty deque",
+			input:      []int{},
+			descending: []pair{},
+			backward:   []pair{},
 		},
 		{
-			name:     "single element",
-			input:    []int{1},
-			expected: []pair{{0, 1}},
+			name:       "single element",
+			input:      []int{1},
+			descending: []pair{{0, 1}},
+			backward:   []pair{{0, 1}},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := New[int]()
-			for _, v := range tt.input {
-				d.list.PushBack(v)
-			}
+			d.PushBack(tt.input...)
 
 			var result = []pair{}
+			for i, v := range d.DescendingIterator() {
+				result = append(result, pair{i, v})
+			}
+			assert.Equal(t, tt.descending, result)
+
+			result = []pair{}
 			for i, v := range d.DescendingeIterator() {
 				result = append(result, pair{i, v})
 			}
+			assert.Equal(t, tt.descending, result)
 
-			assert.Equal(t, tt.expected, result)
+			result = []pair{}
+			for i, v := range d.Backward() {
+				result = append(result, pair{i, v})
+				got, ok := d.Get(i)
+				assert.True(t, ok)
+				assert.Equal(t, v, got)
+			}
+			assert.Equal(t, tt.backward, result)
+		})
+	}
+
+	t.Run("wrapped buffer", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushFront(1, 2))
+		assert.NoError(t, d.PushBack(3, 4))
+
+		var result []int
+		for _, v := range d.DescendingIterator() {
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{4, 3, 2, 1}, result)
+	})
+
+	t.Run("break", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3, 4))
+
+		var result []int
+		for _, v := range d.Backward() {
+			if v == 2 {
+				break
+			}
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{4, 3}, result)
+	})
+}
+
+func TestDeque_Values(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushFront(2, 3))
+	assert.NoError(t, d.PushBack(4))
+	assert.NoError(t, d.PushFront(1))
+
+	assert.Equal(t, []int{1, 2, 3, 4}, slices.Collect(d.Values()))
+	assert.Equal(t, map[int]int{0: 1, 1: 2, 2: 3, 3: 4}, maps.Collect(d.Backward()))
+	assert.Empty(t, slices.Collect(New[int]().Values()))
+
+	var result []int
+	for v := range d.Values() {
+		if v == 3 {
+			break
+		}
+		result = append(result, v)
+	}
+	assert.Equal(t, []int{1, 2}, result)
+}
+
+func TestDeque_Range(t *testing.T) {
+	type pair struct {
+		i int
+		v int
+	}
+
+	d := New[int]()
+	assert.NoError(t, d.PushFront(10, 20))
+	assert.NoError(t, d.PushBack(30, 40, 50))
+
+	tests := []struct {
+		name     string
+		from, to int
+		forward  []pair
+	}{
+		{"whole deque", 0, 5, []pair{{0, 10}, {1, 20}, {2, 30}, {3, 40}, {4, 50}}},
+		{"middle", 1, 3, []pair{{1, 20}, {2, 30}}},
+		{"clamped", -2, 10, []pair{{0, 10}, {1, 20}, {2, 30}, {3, 40}, {4, 50}}},
+		{"tail", 3, 10, []pair{{3, 40}, {4, 50}}},
+		{"empty range", 2, 2, []pair{}},
+		{"inverted range", 3, 1, []pair{}},
+		{"past the end", 7, 9, []pair{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := []pair{}
+			for i, v := range d.Range(tt.from, tt.to) {
+				result = append(result, pair{i, v})
+			}
+			assert.Equal(t, tt.forward, result)
+
+			result = []pair{}
+			for i, v := range d.RangeBackward(tt.from, tt.to) {
+				result = append(result, pair{i, v})
+			}
+			backward := slices.Clone(tt.forward)
+			slices.Reverse(backward)
+			assert.Equal(t, backward, result)
 		})
 	}
+
+	t.Run("break", func(t *testing.T) {
+		var result []int
+		for _, v := range d.RangeBackward(1, 5) {
+			if v == 20 {
+				break
+			}
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{50, 40, 30}, result)
+	})
 }
 
 func TestDeque_Rotate(t *testing.T) {
@@ -501,9 +1031,7 @@ func TestDeque_Rotate(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			d := New[int]()
-			for _, v := range tt.input {
-				d.list.PushBack(v)
-			}
+			d.PushBack(tt.input...)
 
 			d.Rotate(tt.n)
 			assert.Equal(t, tt.expected, d.ToArray())
@@ -511,6 +1039,532 @@ func TestDeque_Rotate(t *testing.T) {
 	}
 }
 
+func TestDeque_WrapAround(t *testing.T) {
+	d := New[int]()
+
+	// Move the head past the middle of the buffer so that
+	// subsequent pushes wrap around its end
+	d.PushBack(make([]int, minCapacity-2)...)
+	for i := 0; i < minCapacity-2; i++ {
+		_, err := d.PopFront()
+		assert.NoError(t, err)
+	}
+
+	d.PushBack(1, 2, 3, 4)
+	d.PushFront(0)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, d.ToArray())
+
+	for i := 0; i < 5; i++ {
+		val, ok := d.Get(i)
+		assert.True(t, ok)
+		assert.Equal(t, i, val)
+	}
+
+	d.Rotate(2)
+	assert.Equal(t, []int{3, 4, 0, 1, 2}, d.ToArray())
+
+	d.Reverse()
+	assert.Equal(t, []int{2, 1, 0, 4, 3}, d.ToArray())
+}
+
+func TestDeque_GrowAndShrink(t *testing.T) {
+	d := New[int]()
+
+	const n = 1000
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			d.PushBack(i)
+		} else {
+			d.PushFront(i)
+		}
+	}
+	assert.Equal(t, n, d.Len())
+	assert.Equal(t, 1024, len(d.buf))
+
+	front, err := d.Front()
+	assert.NoError(t, err)
+	assert.Equal(t, n-1, front)
+
+	back, err := d.Back()
+	assert.NoError(t, err)
+	assert.Equal(t, n-2, back)
+
+	for i := 0; i < n-1; i++ {
+		_, err := d.PopBack()
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, d.Len())
+	assert.Equal(t, minCapacity, len(d.buf))
+
+	val, err := d.PopFront()
+	assert.NoError(t, err)
+	assert.Equal(t, n-1, val)
+}
+
+func TestDeque_RotateFullBuffer(t *testing.T) {
+	d := New[int]()
+	input := make([]int, minCapacity)
+	for i := range input {
+		input[i] = i
+	}
+	d.PushBack(input...)
+
+	d.Rotate(3)
+	d.Rotate(-1)
+	expected := append(append([]int{}, input[minCapacity-2:]...), input[:minCapacity-2]...)
+	assert.Equal(t, expected, d.ToArray())
+}
+
+func TestPopFrontWait(t *testing.T) {
+	t.Run("returns available element immediately", func(t *testing.T) {
+		d := New[int]()
+		d.PushBack(1, 2)
+
+		val, err := d.PopFrontWait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+	})
+
+	t.Run("blocks until element is pushed", func(t *testing.T) {
+		d := New[int]()
+		result := make(chan int)
+
+		go func() {
+			val, err := d.PopFrontWait(context.Background())
+			assert.NoError(t, err)
+			result <- val
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		d.PushBack(42)
+
+		select {
+		case val := <-result:
+			assert.Equal(t, 42, val)
+		case <-time.After(time.Second):
+			t.Fatal("PopFrontWait did not return after PushBack")
+		}
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		d := New[int]()
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		_, err := d.PopFrontWait(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("context deadline exceeded", func(t *testing.T) {
+		d := New[int]()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := d.PopFrontWait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("closed deque", func(t *testing.T) {
+		d := New[int]()
+		d.PushBack(1)
+		d.Close()
+
+		val, err := d.PopFrontWait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+
+		_, err = d.PopFrontWait(context.Background())
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+}
+
+func TestPopBackWait(t *testing.T) {
+	d := New[int]()
+	result := make(chan int)
+
+	go func() {
+		val, err := d.PopBackWait(context.Background())
+		assert.NoError(t, err)
+		result <- val
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	d.PushFront(1, 2, 3)
+
+	select {
+	case val := <-result:
+		assert.Equal(t, 3, val)
+	case <-time.After(time.Second):
+		t.Fatal("PopBackWait did not return after PushFront")
+	}
+}
+
+func TestClose_ReleasesWaiters(t *testing.T) {
+	d := New[int]()
+	const waiters = 10
+
+	var wg sync.WaitGroup
+	errs := make(chan error, waiters)
+	for i := 0; i < waiters; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := d.PopFrontWait(context.Background())
+			errs <- err
+		}()
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	d.Close()
+	d.Close() // must be a no-op
+
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		assert.ErrorIs(t, err, ErrClosed)
+	}
+}
+
+func TestPopWait_ProducerConsumer(t *testing.T) {
+	d := New[int]()
+	const producers = 4
+	const perProducer = 1000
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				d.PushBack(p*perProducer + i)
+			}
+		}(p)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	var mu sync.Mutex
+	var consumers sync.WaitGroup
+	for c := 0; c < producers; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				val, err := d.PopFrontWait(context.Background())
+				if err != nil {
+					assert.ErrorIs(t, err, ErrClosed)
+					return
+				}
+				mu.Lock()
+				assert.False(t, seen[val], "value %d received twice", val)
+				seen[val] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	d.Close()
+	consumers.Wait()
+
+	for i, ok := range seen {
+		assert.True(t, ok, "value %d was lost", i)
+	}
+}
+
+func TestNewBounded(t *testing.T) {
+	d := NewBounded[int](3, OverflowReject)
+	assert.Equal(t, 3, d.Cap())
+	assert.Equal(t, 0, New[int]().Cap())
+
+	assert.Panics(t, func() { NewBounded[int](0, OverflowReject) })
+}
+
+func TestBounded_Reject(t *testing.T) {
+	d := NewBounded[int](3, OverflowReject)
+
+	assert.NoError(t, d.PushBack(1, 2))
+	assert.ErrorIs(t, d.PushBack(3, 4), ErrFullQueue)
+	assert.Equal(t, []int{1, 2}, d.ToArray()) // batch is all-or-nothing
+
+	assert.NoError(t, d.PushFront(0))
+	assert.ErrorIs(t, d.PushFront(-1), ErrFullQueue)
+	assert.ErrorIs(t, d.PushBack(3), ErrFullQueue)
+	assert.Equal(t, []int{0, 1, 2}, d.ToArray())
+
+	_, err := d.PopFront()
+	assert.NoError(t, err)
+	assert.NoError(t, d.PushBack(3))
+	assert.Equal(t, []int{1, 2, 3}, d.ToArray())
+}
+
+func TestBounded_Evict(t *testing.T) {
+	tests := []struct {
+		name     string
+		push     func(d *Deque[int]) error
+		expected []int
+	}{
+		{
+			name:     "push back evicts front",
+			push:     func(d *Deque[int]) error { return d.PushBack(4, 5) },
+			expected: []int{3, 4, 5},
+		},
+		{
+			name:     "push front evicts back",
+			push:     func(d *Deque[int]) error { return d.PushFront(-1, 0) },
+			expected: []int{-1, 0, 1},
+		},
+		{
+			name:     "push back batch larger than capacity",
+			push:     func(d *Deque[int]) error { return d.PushBack(4, 5, 6, 7) },
+			expected: []int{5, 6, 7},
+		},
+		{
+			name:     "push front batch larger than capacity",
+			push:     func(d *Deque[int]) error { return d.PushFront(-3, -2, -1, 0) },
+			expected: []int{-3, -2, -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewBounded[int](3, OverflowEvict)
+			assert.NoError(t, d.PushBack(1, 2, 3))
+
+			assert.NoError(t, tt.push(d))
+			assert.Equal(t, tt.expected, d.ToArray())
+		})
+	}
+}
+
+func TestBounded_Block(t *testing.T) {
+	t.Run("waits for room", func(t *testing.T) {
+		d := NewBounded[int](2, OverflowBlock)
+		assert.NoError(t, d.PushBack(1, 2))
+
+		done := make(chan error)
+		go func() {
+			done <- d.PushBack(3)
+		}()
+
+		select {
+		case <-done:
+			t.Fatal("PushBack did not block on a full deque")
+		case <-time.After(10 * time.Millisecond):
+		}
+
+		_, err := d.PopFront()
+		assert.NoError(t, err)
+
+		select {
+		case err := <-done:
+			assert.NoError(t, err)
+		case <-time.After(time.Second):
+			t.Fatal("PushBack did not return after PopFront")
+		}
+		assert.Equal(t, []int{2, 3}, d.ToArray())
+	})
+
+	t.Run("batch larger than capacity", func(t *testing.T) {
+		d := NewBounded[int](2, OverflowBlock)
+		assert.ErrorIs(t, d.PushBack(1, 2, 3), ErrFullQueue)
+		assert.True(t, d.IsEmpty())
+	})
+
+	t.Run("released by close", func(t *testing.T) {
+		d := NewBounded[int](1, OverflowBlock)
+		assert.NoError(t, d.PushFront(1))
+
+		done := make(chan error)
+		go func() {
+			done <- d.PushFront(2)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		d.Close()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, ErrClosed)
+		case <-time.After(time.Second):
+			t.Fatal("PushFront was not released by Close")
+		}
+	})
+
+	t.Run("close right after room is made", func(t *testing.T) {
+		pushes := map[string]func(d *Deque[int]) error{
+			"PushBack": func(d *Deque[int]) error { return d.PushBack(2) },
+			"Insert":   func(d *Deque[int]) error { return d.Insert(0, 2) },
+			"PushBackHandle": func(d *Deque[int]) error {
+				_, err := d.PushBackHandle(2)
+				return err
+			},
+		}
+
+		for name, push := range pushes {
+			t.Run(name, func(t *testing.T) {
+				for run := 0; run < 50; run++ {
+					d := NewBounded[int](1, OverflowBlock)
+					assert.NoError(t, d.PushBack(1))
+
+					done := make(chan error)
+					go func() {
+						done <- push(d)
+					}()
+
+					time.Sleep(time.Millisecond)
+					_, err := d.PopFront()
+					assert.NoError(t, err)
+					d.Close()
+
+					// The waiter may only win if it got the freed room
+					// before Close
+					if err := <-done; err == nil {
+						assert.Equal(t, []int{2}, d.ToArray())
+					} else {
+						assert.ErrorIs(t, err, ErrClosed)
+						assert.True(t, d.IsEmpty())
+					}
+				}
+			})
+		}
+	})
+
+	t.Run("closed while waiting to be woken", func(t *testing.T) {
+		d := NewBounded[int](1, OverflowBlock)
+		assert.NoError(t, d.PushBack(1))
+
+		done := make(chan error)
+		go func() {
+			done <- d.PushBack(2)
+		}()
+		time.Sleep(10 * time.Millisecond)
+
+		// Make room and close in one critical section, so the waiter can
+		// only observe both
+		d.mu.Lock()
+		d.popFront()
+		d.closed = true
+		d.mu.Unlock()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, ErrClosed)
+			assert.True(t, d.IsEmpty())
+		case <-time.After(time.Second):
+			t.Fatal("PushBack was not released")
+		}
+	})
+}
+
+func TestBounded_Concurrent(t *testing.T) {
+	const capacity = 8
+	d := NewBounded[int](capacity, OverflowBlock)
+
+	var wg sync.WaitGroup
+	for p := 0; p < 4; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 500; i++ {
+				assert.NoError(t, d.PushBack(i))
+				assert.LessOrEqual(t, d.Len(), capacity)
+			}
+		}()
+	}
+
+	received := 0
+	for received < 4*500 {
+		_, err := d.PopFrontWait(context.Background())
+		assert.NoError(t, err)
+		received++
+	}
+	wg.Wait()
+	assert.True(t, d.IsEmpty())
+}
+
+func TestClose_Lifecycle(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(1, 2, 3))
+	assert.False(t, d.IsClosed())
+
+	d.Close()
+	assert.True(t, d.IsClosed())
+
+	assert.ErrorIs(t, d.PushBack(4), ErrClosed)
+	assert.ErrorIs(t, d.PushFront(0), ErrClosed)
+	assert.Equal(t, []int{1, 2, 3}, d.ToArray())
+
+	val, err := d.PopFront()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	val, err = d.PopBack()
+	assert.NoError(t, err)
+	assert.Equal(t, 3, val)
+
+	val, err = d.PopFront()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, val)
+
+	_, err = d.PopFront()
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.NotErrorIs(t, err, ErrEmptyQueue)
+
+	_, err = d.PopBack()
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestDrain(t *testing.T) {
+	t.Run("yields remaining elements of a closed deque", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+		d.Close()
+
+		var result []int
+		for v := range d.Drain() {
+			result = append(result, v)
+		}
+		assert.Equal(t, []int{1, 2, 3}, result)
+		assert.True(t, d.IsEmpty())
+	})
+
+	t.Run("waits for producers until close", func(t *testing.T) {
+		d := New[int]()
+
+		go func() {
+			for i := 0; i < 100; i++ {
+				assert.NoError(t, d.PushBack(i))
+			}
+			d.Close()
+		}()
+
+		var result []int
+		for v := range d.Drain() {
+			result = append(result, v)
+		}
+		assert.Len(t, result, 100)
+		for i, v := range result {
+			assert.Equal(t, i, v)
+		}
+	})
+
+	t.Run("break stops draining", func(t *testing.T) {
+		d := New[int]()
+		assert.NoError(t, d.PushBack(1, 2, 3))
+
+		for v := range d.Drain() {
+			if v == 2 {
+				break
+			}
+		}
+		assert.Equal(t, []int{3}, d.ToArray())
+	})
+}
+
 func TestConcurrentAccess(t *testing.T) {
 	d := New[int]()
 	stop := make(chan struct{})
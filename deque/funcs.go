@@ -0,0 +1,106 @@
+package deque
+
+import (
+	"cmp"
+	"slices"
+)
+
+// Contains reports whether value is present in the deque
+func Contains[T comparable](d *Deque[T], value T) bool {
+	return Index(d, value) >= 0
+}
+
+// Index returns the index of the first occurrence of value in the deque,
+// or -1 if it is not present
+func Index[T comparable](d *Deque[T], value T) int {
+	d.rlockCompacted()
+	defer d.mu.RUnlock()
+
+	for i := 0; i < d.count; i++ {
+		if d.buf[d.at(i)] == value {
+			return i
+		}
+	}
+	return -1
+}
+
+// CountOf returns the number of occurrences of value in the deque
+func CountOf[T comparable](d *Deque[T], value T) int {
+	d.rlockCompacted()
+	defer d.mu.RUnlock()
+
+	count := 0
+	for i := 0; i < d.count; i++ {
+		if d.buf[d.at(i)] == value {
+			count++
+		}
+	}
+	return count
+}
+
+// Equal reports whether both deques hold the same elements in the same
+// order. The deques are locked one after the other rather than together,
+// so the result is only meaningful if neither is modified concurrently
+func Equal[T comparable](a, b *Deque[T]) bool {
+	if a == b {
+		return true
+	}
+
+	values := a.ToArray()
+
+	b.rlockCompacted()
+	defer b.mu.RUnlock()
+
+	if len(values) != b.count {
+		return false
+	}
+	for i, v := range values {
+		if b.buf[b.at(i)] != v {
+			return false
+		}
+	}
+	return true
+}
+
+// Sort sorts the elements of the deque in ascending order, in place
+func Sort[T cmp.Ordered](d *Deque[T]) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	slices.Sort(d.linearize())
+	d.invalidateCursors()
+	d.version++
+}
+
+// SortFunc sorts the elements of the deque in place in the order given by
+// cmp, which has the same contract as slices.SortFunc.
+// The sort is not guaranteed to be stable
+func SortFunc[T any](d *Deque[T], cmp func(a, b T) int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	slices.SortFunc(d.linearize(), cmp)
+	d.invalidateCursors()
+	d.version++
+}
+
+// SortStableFunc sorts the elements of the deque in place in the order
+// given by cmp, keeping the original order of equal elements
+func SortStableFunc[T any](d *Deque[T], cmp func(a, b T) int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	slices.SortStableFunc(d.linearize(), cmp)
+	d.invalidateCursors()
+	d.version++
+}
+
+// linearize makes the elements contiguous in the buffer, dropping the
+// holes, and returns them as a slice aliasing it.
+// Caller must hold the write lock
+func (d *Deque[T]) linearize() []T {
+	if d.holes > 0 || d.head+d.count > len(d.buf) {
+		d.resize(len(d.buf))
+	}
+	return d.buf[d.head : d.head+d.count]
+}
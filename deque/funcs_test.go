@@ -0,0 +1,82 @@
+package deque
+
+import (
+	"cmp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// wrapped returns a deque holding values whose elements wrap around the
+// end of the underlying buffer
+func wrapped[T any](values ...T) *Deque[T] {
+	d := New[T]()
+	for i := len(values) - 1; i >= 0; i-- {
+		d.PushFront(values[i])
+	}
+	return d
+}
+
+func TestContainsIndexCountOf(t *testing.T) {
+	d := wrapped(1, 2, 3, 2)
+
+	assert.True(t, Contains(d, 2))
+	assert.False(t, Contains(d, 5))
+
+	assert.Equal(t, 1, Index(d, 2))
+	assert.Equal(t, 2, Index(d, 3))
+	assert.Equal(t, -1, Index(d, 5))
+
+	assert.Equal(t, 2, CountOf(d, 2))
+	assert.Equal(t, 1, CountOf(d, 1))
+	assert.Equal(t, 0, CountOf(d, 5))
+
+	empty := New[string]()
+	assert.False(t, Contains(empty, ""))
+	assert.Equal(t, -1, Index(empty, ""))
+	assert.Equal(t, 0, CountOf(empty, ""))
+}
+
+func TestEqual(t *testing.T) {
+	a := New[int]()
+	assert.NoError(t, a.PushBack(1, 2, 3))
+
+	assert.True(t, Equal(a, a))
+	assert.True(t, Equal(a, wrapped(1, 2, 3)))
+	assert.True(t, Equal(New[int](), New[int]()))
+
+	assert.False(t, Equal(a, wrapped(1, 2)))
+	assert.False(t, Equal(a, wrapped(1, 2, 4)))
+	assert.False(t, Equal(a, wrapped(3, 2, 1)))
+}
+
+func TestSort(t *testing.T) {
+	d := wrapped(5, 3, 9, 1, 7)
+	Sort(d)
+	assert.Equal(t, []int{1, 3, 5, 7, 9}, d.ToArray())
+
+	// The deque keeps working normally after being linearized
+	assert.NoError(t, d.PushFront(0))
+	assert.NoError(t, d.PushBack(10))
+	assert.Equal(t, []int{0, 1, 3, 5, 7, 9, 10}, d.ToArray())
+
+	empty := New[int]()
+	Sort(empty)
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestSortFunc(t *testing.T) {
+	d := wrapped(5, 3, 9, 1, 7)
+	SortFunc(d, func(a, b int) int { return cmp.Compare(b, a) })
+	assert.Equal(t, []int{9, 7, 5, 3, 1}, d.ToArray())
+}
+
+func TestSortStableFunc(t *testing.T) {
+	d := wrapped("bb", "a", "cc", "b", "aa", "c")
+	SortStableFunc(d, func(a, b string) int { return cmp.Compare(len(a), len(b)) })
+	assert.Equal(t, []string{"a", "b", "c", "bb", "cc", "aa"}, d.ToArray())
+
+	SortStableFunc(d, func(a, b string) int { return strings.Compare(a[:1], b[:1]) })
+	assert.Equal(t, []string{"a", "aa", "b", "bb", "c", "cc"}, d.ToArray())
+}
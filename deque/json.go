@@ -0,0 +1,61 @@
+package deque
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// MarshalJSON implements json.Marshaler. The deque is encoded as a JSON
+// array of its elements from front to back, taken from a snapshot under
+// the read lock.
+// Since the method has a pointer receiver, a Deque embedded by value in
+// another struct is only encoded this way if that struct is addressable;
+// prefer *Deque fields
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.ToArray())
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It decodes a JSON array and
+// replaces the contents of the deque with its elements, front to back.
+// A JSON null leaves the deque unchanged. On a bounded deque an array
+// longer than the capacity fails with ErrFullQueue, except under
+// OverflowEvict, which keeps its last Cap() elements as PushBack would.
+// Returns ErrClosed if the deque has been closed
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	const fancName = "(*Deque[T]).UnmarshalJSON"
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%s: %w", fancName, err)
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+	if d.capacity > 0 && len(values) > d.capacity {
+		if d.policy != OverflowEvict {
+			return fmt.Errorf("%s: %w", fancName, ErrFullQueue)
+		}
+		values = values[len(values)-d.capacity:]
+	}
+
+	d.invalidateCursors()
+	d.buf = nil
+	d.head = 0
+	d.count = 0
+	d.holes = 0
+	d.dead = nil
+
+	d.pushBack(values)
+	d.signalNotEmpty(len(values))
+	d.signalNotFull()
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package deque
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque_MarshalJSON(t *testing.T) {
+	d := wrapped(1, 2, 3)
+	data, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[1, 2, 3]`, string(data))
+
+	data, err = json.Marshal(New[string]())
+	assert.NoError(t, err)
+	assert.Equal(t, `[]`, string(data))
+}
+
+func TestDeque_UnmarshalJSON(t *testing.T) {
+	d := New[int]()
+	assert.NoError(t, d.PushBack(9, 9))
+
+	assert.NoError(t, json.Unmarshal([]byte(`[1, 2, 3]`), d))
+	assert.Equal(t, []int{1, 2, 3}, d.ToArray())
+
+	// The deque keeps working at both ends after being decoded
+	assert.NoError(t, d.PushFront(0))
+	assert.NoError(t, d.PushBack(4))
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, d.ToArray())
+
+	assert.NoError(t, json.Unmarshal([]byte(`null`), d))
+	assert.Equal(t, 5, d.Len())
+
+	assert.NoError(t, json.Unmarshal([]byte(`[]`), d))
+	assert.True(t, d.IsEmpty())
+
+	assert.Error(t, json.Unmarshal([]byte(`{"a": 1}`), d))
+	assert.Error(t, json.Unmarshal([]byte(`["a"]`), d))
+
+	t.Run("bounded", func(t *testing.T) {
+		d := NewBounded[int](2, OverflowReject)
+		assert.ErrorIs(t, json.Unmarshal([]byte(`[1, 2, 3]`), d), ErrFullQueue)
+
+		d = NewBounded[int](2, OverflowEvict)
+		assert.NoError(t, json.Unmarshal([]byte(`[1, 2, 3]`), d))
+		assert.Equal(t, []int{2, 3}, d.ToArray())
+	})
+
+	t.Run("closed", func(t *testing.T) {
+		d := New[int]()
+		d.Close()
+		assert.ErrorIs(t, json.Unmarshal([]byte(`[1]`), d), ErrClosed)
+	})
+
+	t.Run("invalidates cursors", func(t *testing.T) {
+		d := New[int]()
+		c, err := d.PushBackHandle(1)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal([]byte(`[1]`), d))
+		assert.False(t, c.Valid())
+	})
+}
+
+func TestDeque_JSONRoundTrip(t *testing.T) {
+	t.Run("nested deques", func(t *testing.T) {
+		inner1, inner2 := wrapped(1, 2), wrapped(3)
+		d := New[*Deque[int]]()
+		assert.NoError(t, d.PushBack(inner1, inner2))
+
+		data, err := json.Marshal(d)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `[[1, 2], [3]]`, string(data))
+
+		decoded := New[*Deque[int]]()
+		assert.NoError(t, json.Unmarshal(data, decoded))
+		assert.Equal(t, 2, decoded.Len())
+
+		first, _ := decoded.Get(0)
+		second, _ := decoded.Get(1)
+		assert.Equal(t, []int{1, 2}, first.ToArray())
+		assert.Equal(t, []int{3}, second.ToArray())
+	})
+
+	t.Run("generic element types", func(t *testing.T) {
+		d := New[map[string][]Pair[int, string]]()
+		assert.NoError(t, d.PushBack(
+			map[string][]Pair[int, string]{"a": {{1, "x"}, {2, "y"}}},
+			map[string][]Pair[int, string]{"b": nil},
+		))
+
+		data, err := json.Marshal(d)
+		assert.NoError(t, err)
+
+		decoded := New[map[string][]Pair[int, string]]()
+		assert.NoError(t, json.Unmarshal(data, decoded))
+		assert.Equal(t, d.ToArray(), decoded.ToArray())
+	})
+
+	t.Run("struct field", func(t *testing.T) {
+		type payload struct {
+			Name  string                  `json:"name"`
+			Queue *Deque[Pair[int, bool]] `json:"queue"`
+		}
+
+		in := payload{Name: "jobs", Queue: New[Pair[int, bool]]()}
+		assert.NoError(t, in.Queue.PushBack(Pair[int, bool]{1, true}, Pair[int, bool]{2, false}))
+
+		data, err := json.Marshal(in)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"name": "jobs", "queue": [{"First": 1, "Second": true}, {"First": 2, "Second": false}]}`, string(data))
+
+		var out payload
+		assert.NoError(t, json.Unmarshal(data, &out))
+		assert.Equal(t, "jobs", out.Name)
+		assert.Equal(t, in.Queue.ToArray(), out.Queue.ToArray())
+	})
+}
@@ -0,0 +1,126 @@
+package deque
+
+// Pair holds one element of each of two deques combined by Zip
+type Pair[T, U any] struct {
+	First  T
+	Second U
+}
+
+// Map returns a new deque holding f applied to every element of d, in the
+// same order. f is called on a snapshot of d taken under its lock, so it
+// may safely access d
+func Map[T, U any](d *Deque[T], f func(T) U) *Deque[U] {
+	values := d.ToArray()
+
+	result := make([]U, len(values))
+	for i, v := range values {
+		result[i] = f(v)
+	}
+	return fromSlice(result)
+}
+
+// Filter returns a new deque holding the elements of d that satisfy pred,
+// in the same order. pred is called on a snapshot of d
+func Filter[T any](d *Deque[T], pred func(T) bool) *Deque[T] {
+	values := d.ToArray()
+
+	kept := values[:0]
+	for _, v := range values {
+		if pred(v) {
+			kept = append(kept, v)
+		}
+	}
+	return fromSlice(kept)
+}
+
+// Reduce folds the elements of d from front to back into a single value,
+// starting with init. f is called on a snapshot of d
+func Reduce[T, A any](d *Deque[T], init A, f func(acc A, value T) A) A {
+	acc := init
+	for _, v := range d.ToArray() {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Partition splits the elements of d into two new deques: the ones that
+// satisfy pred and the ones that do not, both keeping the original order.
+// pred is called on a snapshot of d
+func Partition[T any](d *Deque[T], pred func(T) bool) (matched, rest *Deque[T]) {
+	var yes, no []T
+	for _, v := range d.ToArray() {
+		if pred(v) {
+			yes = append(yes, v)
+		} else {
+			no = append(no, v)
+		}
+	}
+	return fromSlice(yes), fromSlice(no)
+}
+
+// Chunk splits a snapshot of d into consecutive slices of size elements
+// and returns them as a new deque. The last chunk holds the remaining
+// elements and may be shorter. It panics if size is not positive
+func Chunk[T any](d *Deque[T], size int) *Deque[[]T] {
+	if size <= 0 {
+		panic("deque: chunk size must be positive")
+	}
+
+	values := d.ToArray()
+
+	chunks := make([][]T, 0, (len(values)+size-1)/size)
+	for len(values) > 0 {
+		n := min(size, len(values))
+		chunks = append(chunks, values[:n:n])
+		values = values[n:]
+	}
+	return fromSlice(chunks)
+}
+
+// Window returns a new deque holding every run of size consecutive
+// elements of a snapshot of d, front to back. A deque with fewer than
+// size elements has no windows. The windows share memory with each other,
+// so modifying one modifies the overlapping ones.
+// It panics if size is not positive
+func Window[T any](d *Deque[T], size int) *Deque[[]T] {
+	if size <= 0 {
+		panic("deque: window size must be positive")
+	}
+
+	values := d.ToArray()
+	if len(values) < size {
+		return New[[]T]()
+	}
+
+	windows := make([][]T, 0, len(values)-size+1)
+	for i := 0; i+size <= len(values); i++ {
+		windows = append(windows, values[i:i+size:i+size])
+	}
+	return fromSlice(windows)
+}
+
+// Zip returns a new deque pairing the elements of a and b by position.
+// The result is as long as the shorter of the two deques.
+// Each deque is snapshotted under its own lock
+func Zip[T, U any](a *Deque[T], b *Deque[U]) *Deque[Pair[T, U]] {
+	first, second := a.ToArray(), b.ToArray()
+
+	pairs := make([]Pair[T, U], min(len(first), len(second)))
+	for i := range pairs {
+		pairs[i] = Pair[T, U]{First: first[i], Second: second[i]}
+	}
+	return fromSlice(pairs)
+}
+
+// fromSlice returns a new deque holding values front to back.
+// The deque takes a copy of values, in a buffer sized for them
+func fromSlice[T any](values []T) *Deque[T] {
+	d := New[T]()
+	if len(values) == 0 {
+		return d
+	}
+
+	d.reserve(len(values))
+	d.count = copy(d.buf, values)
+	return d
+}
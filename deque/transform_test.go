@@ -0,0 +1,86 @@
+package deque
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	d := wrapped(1, 2, 3)
+	result := Map(d, strconv.Itoa)
+	assert.Equal(t, []string{"1", "2", "3"}, result.ToArray())
+	assert.Equal(t, []int{1, 2, 3}, d.ToArray()) // source is untouched
+
+	// f may access the source deque without deadlocking
+	sums := Map(d, func(v int) int { return v + d.Len() })
+	assert.Equal(t, []int{4, 5, 6}, sums.ToArray())
+
+	assert.True(t, Map(New[int](), strconv.Itoa).IsEmpty())
+}
+
+func TestFilter(t *testing.T) {
+	d := wrapped(1, 2, 3, 4, 5)
+	result := Filter(d, func(v int) bool { return v%2 == 1 })
+	assert.Equal(t, []int{1, 3, 5}, result.ToArray())
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, d.ToArray())
+
+	// The result is an independent, fully working deque
+	assert.NoError(t, result.PushFront(0))
+	assert.Equal(t, []int{0, 1, 3, 5}, result.ToArray())
+}
+
+func TestReduce(t *testing.T) {
+	d := wrapped(1, 2, 3, 4)
+	sum := Reduce(d, 0, func(acc, v int) int { return acc + v })
+	assert.Equal(t, 10, sum)
+
+	joined := Reduce(d, "", func(acc string, v int) string { return acc + strconv.Itoa(v) })
+	assert.Equal(t, "1234", joined)
+
+	assert.Equal(t, 7, Reduce(New[int](), 7, func(acc, v int) int { return acc + v }))
+}
+
+func TestPartition(t *testing.T) {
+	d := wrapped(1, 2, 3, 4, 5)
+	even, odd := Partition(d, func(v int) bool { return v%2 == 0 })
+	assert.Equal(t, []int{2, 4}, even.ToArray())
+	assert.Equal(t, []int{1, 3, 5}, odd.ToArray())
+
+	matched, rest := Partition(New[int](), func(int) bool { return true })
+	assert.True(t, matched.IsEmpty())
+	assert.True(t, rest.IsEmpty())
+}
+
+func TestChunk(t *testing.T) {
+	d := wrapped(1, 2, 3, 4, 5)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, Chunk(d, 2).ToArray())
+	assert.Equal(t, [][]int{{1, 2, 3, 4, 5}}, Chunk(d, 10).ToArray())
+	assert.True(t, Chunk(New[int](), 3).IsEmpty())
+
+	// Chunks do not share capacity, so appending to one leaves the next intact
+	chunks := Chunk(d, 2).ToArray()
+	_ = append(chunks[0], 99)
+	assert.Equal(t, []int{3, 4}, chunks[1])
+
+	assert.Panics(t, func() { Chunk(d, 0) })
+}
+
+func TestWindow(t *testing.T) {
+	d := wrapped(1, 2, 3, 4)
+	assert.Equal(t, [][]int{{1, 2}, {2, 3}, {3, 4}}, Window(d, 2).ToArray())
+	assert.Equal(t, [][]int{{1, 2, 3, 4}}, Window(d, 4).ToArray())
+	assert.True(t, Window(d, 5).IsEmpty())
+
+	assert.Panics(t, func() { Window(d, 0) })
+}
+
+func TestZip(t *testing.T) {
+	a := wrapped(1, 2, 3)
+	b := wrapped("a", "b")
+
+	expected := []Pair[int, string]{{1, "a"}, {2, "b"}}
+	assert.Equal(t, expected, Zip(a, b).ToArray())
+	assert.True(t, Zip(a, New[string]()).IsEmpty())
+}
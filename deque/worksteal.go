@@ -0,0 +1,156 @@
+package deque
+
+import (
+	"sync/atomic"
+)
+
+// wsInitialSize is the initial size of the circular array of a
+// WorkStealing deque. It must be a power of two
+const wsInitialSize = 32
+
+// wsArray is the growable circular array behind a WorkStealing deque.
+// Slots hold pointers so that thieves can read them atomically while
+// the owner writes other slots
+type wsArray[T any] struct {
+	slots []atomic.Pointer[T]
+	mask  int64
+}
+
+// newWSArray creates an array with size slots, size being a power of two
+func newWSArray[T any](size int64) *wsArray[T] {
+	return &wsArray[T]{
+		slots: make([]atomic.Pointer[T], size),
+		mask:  size - 1,
+	}
+}
+
+func (a *wsArray[T]) size() int64 {
+	return int64(len(a.slots))
+}
+
+func (a *wsArray[T]) get(i int64) *T {
+	return a.slots[i&a.mask].Load()
+}
+
+func (a *wsArray[T]) put(i int64, v *T) {
+	a.slots[i&a.mask].Store(v)
+}
+
+// grow returns a copy of the array twice as large holding the
+// elements in [top, bottom)
+func (a *wsArray[T]) grow(top, bottom int64) *wsArray[T] {
+	grown := newWSArray[T](a.size() * 2)
+	for i := top; i < bottom; i++ {
+		grown.put(i, a.get(i))
+	}
+	return grown
+}
+
+// WorkStealing is a Chase–Lev work-stealing deque for schedulers.
+// A single owner goroutine pushes and pops at the bottom without locks,
+// while any number of thieves take elements from the top with Steal.
+// Only thieves and an owner popping the last element contend, on a
+// single CAS of the top index. The underlying circular array grows
+// as needed.
+// Push and Pop must only be called by the owner goroutine
+type WorkStealing[T any] struct {
+	top    atomic.Int64
+	_      [cacheLineSize - 8]byte
+	bottom atomic.Int64
+	array  atomic.Pointer[wsArray[T]]
+}
+
+// cacheLineSize is used to keep the indices touched by thieves and by
+// the owner on separate cache lines
+const cacheLineSize = 64
+
+// NewWorkStealing creates and returns a new empty WorkStealing deque
+func NewWorkStealing[T any]() *WorkStealing[T] {
+	w := &WorkStealing[T]{}
+	w.array.Store(newWSArray[T](wsInitialSize))
+	return w
+}
+
+// Len returns the number of elements in the deque.
+// Under concurrent use the result is only a snapshot
+func (w *WorkStealing[T]) Len() int {
+	b := w.bottom.Load()
+	t := w.top.Load()
+	return int(max(b-t, 0))
+}
+
+// IsEmpty returns true if the deque contains no elements.
+// Under concurrent use the result is only a snapshot
+func (w *WorkStealing[T]) IsEmpty() bool {
+	return w.Len() == 0
+}
+
+// Push adds a value to the bottom of the deque, growing the
+// underlying array if it is full. Must only be called by the owner
+func (w *WorkStealing[T]) Push(value T) {
+	b := w.bottom.Load()
+	t := w.top.Load()
+	a := w.array.Load()
+
+	if b-t >= a.size() {
+		a = a.grow(t, b)
+		w.array.Store(a)
+	}
+
+	a.put(b, &value)
+	w.bottom.Store(b + 1)
+}
+
+// Pop removes and returns the value at the bottom of the deque, the
+// most recently pushed one. It returns the zero value of type T and
+// false if the deque is empty or a thief took the last element.
+// Must only be called by the owner
+func (w *WorkStealing[T]) Pop() (T, bool) {
+	b := w.bottom.Load() - 1
+	a := w.array.Load()
+
+	// Reserve the bottom element before looking at top, so that
+	// thieves see the smaller bottom
+	w.bottom.Store(b)
+	t := w.top.Load()
+
+	if t > b {
+		// The deque was empty
+		w.bottom.Store(b + 1)
+		return zeroval[T](), false
+	}
+
+	v := a.get(b)
+	if t == b {
+		// Last element: race the thieves for it
+		won := w.top.CompareAndSwap(t, t+1)
+		w.bottom.Store(b + 1)
+		if !won {
+			return zeroval[T](), false
+		}
+		return *v, true
+	}
+
+	a.put(b, nil) // allow GC of the removed value
+	return *v, true
+}
+
+// Steal removes and returns the value at the top of the deque, the
+// least recently pushed one. It returns the zero value of type T and
+// false if the deque is empty. Safe to call from any goroutine
+func (w *WorkStealing[T]) Steal() (T, bool) {
+	for {
+		t := w.top.Load()
+		b := w.bottom.Load()
+		if t >= b {
+			return zeroval[T](), false
+		}
+
+		// The element must be read before the CAS: once top moves
+		// the owner may reuse the slot
+		v := w.array.Load().get(t)
+		if w.top.CompareAndSwap(t, t+1) {
+			return *v, true
+		}
+	}
+}
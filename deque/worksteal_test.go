@@ -0,0 +1,192 @@
+package deque
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWorkStealing(t *testing.T) {
+	w := NewWorkStealing[int]()
+	assert.NotNil(t, w)
+	assert.Equal(t, 0, w.Len())
+	assert.True(t, w.IsEmpty())
+
+	_, ok := w.Pop()
+	assert.False(t, ok)
+	_, ok = w.Steal()
+	assert.False(t, ok)
+}
+
+func TestWorkStealing_Order(t *testing.T) {
+	w := NewWorkStealing[int]()
+	for i := 1; i <= 5; i++ {
+		w.Push(i)
+	}
+	assert.Equal(t, 5, w.Len())
+
+	// The owner works LIFO from the bottom
+	val, ok := w.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 5, val)
+
+	// Thieves take the oldest elements from the top
+	val, ok = w.Steal()
+	assert.True(t, ok)
+	assert.Equal(t, 1, val)
+
+	val, ok = w.Steal()
+	assert.True(t, ok)
+	assert.Equal(t, 2, val)
+
+	val, ok = w.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 4, val)
+
+	val, ok = w.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, val)
+
+	_, ok = w.Pop()
+	assert.False(t, ok)
+	assert.True(t, w.IsEmpty())
+}
+
+func TestWorkStealing_Grow(t *testing.T) {
+	w := NewWorkStealing[int]()
+	const n = wsInitialSize*4 + 3
+
+	// Steal a few first so that top is not aligned with the array
+	for i := 0; i < 5; i++ {
+		w.Push(i)
+	}
+	for i := 0; i < 5; i++ {
+		_, ok := w.Steal()
+		assert.True(t, ok)
+	}
+
+	for i := 0; i < n; i++ {
+		w.Push(i)
+	}
+	assert.Equal(t, n, w.Len())
+
+	for i := 0; i < n/2; i++ {
+		val, ok := w.Steal()
+		assert.True(t, ok)
+		assert.Equal(t, i, val)
+	}
+	for i := n - 1; i >= n/2; i-- {
+		val, ok := w.Pop()
+		assert.True(t, ok)
+		assert.Equal(t, i, val)
+	}
+	assert.True(t, w.IsEmpty())
+}
+
+// TestWorkStealing_Stress runs an owner that pushes and pops against
+// several thieves and checks that every element is taken exactly once
+func TestWorkStealing_Stress(t *testing.T) {
+	w := NewWorkStealing[int]()
+	const total = 100000
+	const thieves = 4
+
+	taken := make([]atomic.Int32, total)
+	var ownerDone atomic.Bool
+
+	var wg sync.WaitGroup
+	for i := 0; i < thieves; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				if val, ok := w.Steal(); ok {
+					taken[val].Add(1)
+					continue
+				}
+				if ownerDone.Load() && w.IsEmpty() {
+					return
+				}
+				runtime.Gosched()
+			}
+		}()
+	}
+
+	for i := 0; i < total; i++ {
+		w.Push(i)
+		if i%3 == 0 {
+			if val, ok := w.Pop(); ok {
+				taken[val].Add(1)
+			}
+		}
+	}
+	for {
+		val, ok := w.Pop()
+		if !ok {
+			break
+		}
+		taken[val].Add(1)
+	}
+	ownerDone.Store(true)
+	wg.Wait()
+
+	for i := range taken {
+		if n := taken[i].Load(); n != 1 {
+			t.Fatalf("element %d was taken %d times", i, n)
+		}
+	}
+}
+
+// BenchmarkWorkStealing compares the owner path of WorkStealing with
+// Deque used the same way (PushBack/PopBack) while a thief steals
+func BenchmarkWorkStealing(b *testing.B) {
+	b.Run("WorkStealing", func(b *testing.B) {
+		w := NewWorkStealing[int]()
+		stop := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					w.Steal()
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			w.Push(i)
+			w.Push(i)
+			w.Pop()
+		}
+		close(stop)
+	})
+
+	b.Run("Deque", func(b *testing.B) {
+		d := New[int]()
+		stop := make(chan struct{})
+		go func() {
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					d.PopFront()
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			_ = d.PushBack(i)
+			_ = d.PushBack(i)
+			_, _ = d.PopBack()
+		}
+		close(stop)
+	})
+}
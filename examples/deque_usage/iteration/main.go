@@ -18,7 +18,13 @@ func main() {
 
 	// Reverse iteration
 	fmt.Println("\nReverse iteration:")
-	for i, v := range dq.DescendingeIterator() {
+	for i, v := range dq.DescendingIterator() {
+		fmt.Printf("Index: %d Value: %v\n", i, v)
+	}
+
+	// Reverse iteration with positions in the deque
+	fmt.Println("\nBackward iteration:")
+	for i, v := range dq.Backward() {
 		fmt.Printf("Index: %d Value: %v\n", i, v)
 	}
 }
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Pshimaf-Git/container/queue"
+)
+
+func main() {
+	// Create new generic lock-free FIFO queue
+	q := queue.New[string]()
+
+	q.Enqueue("first")  // -> [first]
+	q.Enqueue("second") // -> [first, second]
+
+	fmt.Println("Len after Enqueue:", q.Len()) // Output: 2
+
+	if v, ok := q.Dequeue(); ok {
+		fmt.Println("Value:", v) // Output: first
+	}
+
+	q.Dequeue()                      // Now queue is empty -> []
+	fmt.Println("Empty:", q.Empty()) // Output: true
+}
@@ -0,0 +1,172 @@
+package pqueue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrNotReady is returned by DelayQueue when it has elements but none of them
+// is due yet
+var ErrNotReady = errors.New("no element is ready yet")
+
+// delayed is an element of a DelayQueue with the time it becomes eligible
+type delayed[T any] struct {
+	value T
+	at    time.Time
+}
+
+// DelayQueue is a thread-safe queue whose elements become eligible only at
+// their scheduled time. Eligible elements come out in order of their
+// scheduled time; this is what retry schedulers and timers usually need
+type DelayQueue[T any] struct {
+	pq      *PriorityQueue[delayed[T]]
+	mu      sync.RWMutex
+	changed *sync.Cond // tied to mu, broadcast whenever waiters must re-check
+	closed  bool
+}
+
+// NewDelay creates and returns a new empty DelayQueue
+func NewDelay[T any]() *DelayQueue[T] {
+	q := &DelayQueue[T]{
+		pq: New(func(a, b delayed[T]) int { return a.at.Compare(b.at) }),
+	}
+	q.changed = sync.NewCond(&q.mu)
+	return q
+}
+
+// Len returns the number of elements in the queue, due or not
+func (q *DelayQueue[T]) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.pq.Len()
+}
+
+// IsEmpty returns true if the queue contains no elements
+func (q *DelayQueue[T]) IsEmpty() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.pq.IsEmpty()
+}
+
+// Push schedules value to become eligible at the given time.
+// Returns ErrClosed if the queue has been closed
+func (q *DelayQueue[T]) Push(value T, at time.Time) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	const fancName = "(*DelayQueue[T]).Push"
+
+	if q.closed {
+		return fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+
+	q.pq.Push(delayed[T]{value: value, at: at})
+	// The new element may be due earlier than the one waiters sleep for
+	q.changed.Broadcast()
+	return nil
+}
+
+// PushAfter schedules value to become eligible after the given delay.
+// Returns ErrClosed if the queue has been closed
+func (q *DelayQueue[T]) PushAfter(value T, delay time.Duration) error {
+	return q.Push(value, time.Now().Add(delay))
+}
+
+// Peek returns the element scheduled first and its scheduled time without
+// removing it, whether it is due or not.
+// Returns ErrEmptyQueue if the queue is empty
+func (q *DelayQueue[T]) Peek() (T, time.Time, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	const fancName = "(*DelayQueue[T]).Peek"
+
+	if q.pq.IsEmpty() {
+		return zeroval[T](), time.Time{}, fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+
+	head := q.pq.items[0].value
+	return head.value, head.at, nil
+}
+
+// Pop removes and returns the element scheduled first if it is due.
+// Returns ErrEmptyQueue if the queue is empty (ErrClosed if it is also
+// closed), or ErrNotReady if no element is due yet
+func (q *DelayQueue[T]) Pop() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	const fancName = "(*DelayQueue[T]).Pop"
+
+	if q.pq.IsEmpty() {
+		if q.closed {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrClosed)
+		}
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+
+	if time.Until(q.pq.items[0].value.at) > 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrNotReady)
+	}
+	return q.pq.removeAt(0).value, nil
+}
+
+// PopWait removes and returns the element scheduled first, blocking until it
+// is due. An element pushed while waiting with an earlier time is picked up.
+// Returns an error wrapping ctx.Err() if the context is done first, or
+// ErrClosed if the queue is closed and has no elements left
+func (q *DelayQueue[T]) PopWait(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	const fancName = "(*DelayQueue[T]).PopWait"
+
+	stop := context.AfterFunc(ctx, q.broadcast)
+	defer stop()
+
+	for {
+		if q.pq.IsEmpty() && q.closed {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrClosed)
+		}
+		if err := ctx.Err(); err != nil {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, err)
+		}
+
+		var timer *time.Timer
+		if !q.pq.IsEmpty() {
+			wait := time.Until(q.pq.items[0].value.at)
+			if wait <= 0 {
+				return q.pq.removeAt(0).value, nil
+			}
+			timer = time.AfterFunc(wait, q.broadcast)
+		}
+
+		q.changed.Wait()
+
+		if timer != nil {
+			timer.Stop()
+		}
+	}
+}
+
+// Close marks the queue as closed and wakes every goroutine blocked in
+// PopWait. After Close every push fails with ErrClosed, while pending
+// elements can still be popped once due; PopWait fails with ErrClosed once
+// the queue is empty.
+// Calling Close more than once has no effect
+func (q *DelayQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.changed.Broadcast()
+}
+
+// broadcast wakes every waiter so that it re-checks its condition
+func (q *DelayQueue[T]) broadcast() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.changed.Broadcast()
+}
@@ -0,0 +1,108 @@
+package pqueue
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDelayQueue_Pop(t *testing.T) {
+	q := NewDelay[string]()
+	_, err := q.Pop()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+
+	now := time.Now()
+	assert.NoError(t, q.Push("later", now.Add(time.Hour)))
+	assert.NoError(t, q.Push("past", now.Add(-time.Minute)))
+	assert.NoError(t, q.Push("older", now.Add(-time.Hour)))
+	assert.Equal(t, 3, q.Len())
+
+	val, at, err := q.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, "older", val)
+	assert.True(t, at.Equal(now.Add(-time.Hour)))
+
+	val, err = q.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, "older", val)
+
+	val, err = q.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, "past", val)
+
+	_, err = q.Pop()
+	assert.ErrorIs(t, err, ErrNotReady)
+	assert.Equal(t, 1, q.Len())
+}
+
+func TestDelayQueue_PopWait(t *testing.T) {
+	t.Run("waits until element is due", func(t *testing.T) {
+		q := NewDelay[int]()
+		start := time.Now()
+		assert.NoError(t, q.PushAfter(1, 30*time.Millisecond))
+
+		val, err := q.PopWait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+		assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	})
+
+	t.Run("picks up earlier element pushed while waiting", func(t *testing.T) {
+		q := NewDelay[int]()
+		assert.NoError(t, q.PushAfter(1, time.Hour))
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			assert.NoError(t, q.PushAfter(2, 10*time.Millisecond))
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		val, err := q.PopWait(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, val)
+	})
+
+	t.Run("waits on empty queue", func(t *testing.T) {
+		q := NewDelay[int]()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			assert.NoError(t, q.Push(3, time.Now()))
+		}()
+
+		val, err := q.PopWait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 3, val)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		q := NewDelay[int]()
+		assert.NoError(t, q.PushAfter(1, time.Hour))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := q.PopWait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Equal(t, 1, q.Len())
+	})
+
+	t.Run("closed queue", func(t *testing.T) {
+		q := NewDelay[int]()
+		assert.NoError(t, q.PushAfter(1, 10*time.Millisecond))
+		q.Close()
+
+		assert.ErrorIs(t, q.PushAfter(2, 0), ErrClosed)
+
+		// Pending elements are still delivered once due
+		val, err := q.PopWait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 1, val)
+
+		_, err = q.PopWait(context.Background())
+		assert.ErrorIs(t, err, ErrClosed)
+	})
+}
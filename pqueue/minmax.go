@@ -0,0 +1,246 @@
+package pqueue
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// MinMax is a double-ended priority queue backed by a min-max heap: both the
+// first and the last element in the order of the comparator can be read in
+// O(1) and removed in O(log n).
+//
+// A bounded MinMax keeps at most Cap() elements; once it is full, pushing a
+// value evicts the worst element (the one PopMax would return), which makes
+// it a top-K tracker for the K elements that come first in the order.
+// It is not safe for concurrent use
+type MinMax[T any] struct {
+	items    []T
+	cmp      func(a, b T) int
+	capacity int // 0 means unbounded
+}
+
+// NewMinMax creates and returns a new empty MinMax ordered by cmp
+func NewMinMax[T any](cmp func(a, b T) int) *MinMax[T] {
+	return &MinMax[T]{cmp: cmp}
+}
+
+// NewBoundedMinMax creates and returns a new empty MinMax ordered by cmp that
+// holds at most capacity elements. It panics if capacity is not positive
+func NewBoundedMinMax[T any](cmp func(a, b T) int, capacity int) *MinMax[T] {
+	if capacity <= 0 {
+		panic("pqueue: capacity must be positive")
+	}
+
+	return &MinMax[T]{cmp: cmp, capacity: capacity}
+}
+
+// Len returns the number of elements in the queue
+func (h *MinMax[T]) Len() int {
+	return len(h.items)
+}
+
+// IsEmpty returns true if the queue contains no elements
+func (h *MinMax[T]) IsEmpty() bool {
+	return len(h.items) == 0
+}
+
+// Cap returns the maximum number of elements the queue can hold,
+// or 0 if the queue is unbounded
+func (h *MinMax[T]) Cap() int {
+	return h.capacity
+}
+
+// PushValue adds one or more values to the queue.
+// On a bounded queue that is full, each value evicts the current maximum if
+// it comes before it, and is dropped itself otherwise. The evicted (or
+// dropped) values are returned in the order they left the queue; the result
+// is nil if nothing was evicted
+func (h *MinMax[T]) PushValue(values ...T) []T {
+	var evicted []T
+
+	for _, v := range values {
+		if h.capacity == 0 || len(h.items) < h.capacity {
+			h.items = append(h.items, v)
+			h.up(len(h.items) - 1)
+			continue
+		}
+
+		i := h.maxIndex()
+		if h.cmp(v, h.items[i]) >= 0 {
+			evicted = append(evicted, v)
+			continue
+		}
+
+		evicted = append(evicted, h.removeAt(i))
+		h.items = append(h.items, v)
+		h.up(len(h.items) - 1)
+	}
+
+	return evicted
+}
+
+// PeekMin returns the first element in the order of the comparator without
+// removing it. Returns an error if the queue is empty
+func (h *MinMax[T]) PeekMin() (T, error) {
+	const fancName = "(*MinMax[T]).PeekMin"
+
+	if len(h.items) == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return h.items[0], nil
+}
+
+// PeekMax returns the last element in the order of the comparator without
+// removing it. Returns an error if the queue is empty
+func (h *MinMax[T]) PeekMax() (T, error) {
+	const fancName = "(*MinMax[T]).PeekMax"
+
+	if len(h.items) == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return h.items[h.maxIndex()], nil
+}
+
+// PopMin removes and returns the first element in the order of the
+// comparator. Returns an error if the queue is empty
+func (h *MinMax[T]) PopMin() (T, error) {
+	const fancName = "(*MinMax[T]).PopMin"
+
+	if len(h.items) == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return h.removeAt(0), nil
+}
+
+// PopMax removes and returns the last element in the order of the
+// comparator. Returns an error if the queue is empty
+func (h *MinMax[T]) PopMax() (T, error) {
+	const fancName = "(*MinMax[T]).PopMax"
+
+	if len(h.items) == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return h.removeAt(h.maxIndex()), nil
+}
+
+// Clear removes all elements from the queue and returns the count of
+// elements that were removed
+func (h *MinMax[T]) Clear() int {
+	cleared := len(h.items)
+	h.items = nil
+	return cleared
+}
+
+// isMinLevel reports whether position i is on a min level of the heap.
+// The root is on level 0, and min and max levels alternate from there
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+// maxIndex returns the position of the maximum, which is the larger child of
+// the root. The heap must not be empty
+func (h *MinMax[T]) maxIndex() int {
+	switch len(h.items) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+
+	if h.cmp(h.items[2], h.items[1]) > 0 {
+		return 2
+	}
+	return 1
+}
+
+// removeAt removes the element at position i of the heap and returns it
+func (h *MinMax[T]) removeAt(i int) T {
+	value := h.items[i]
+	last := len(h.items) - 1
+
+	h.items[i] = h.items[last]
+	h.items[last] = zeroval[T]()
+	h.items = h.items[:last]
+
+	if i < last {
+		h.down(i)
+	}
+	return value
+}
+
+// less reports whether the element at position i comes before the one at j.
+// On max levels the order is inverted, so that the same walks serve both
+func (h *MinMax[T]) less(i, j int, minLevel bool) bool {
+	c := h.cmp(h.items[i], h.items[j])
+	if minLevel {
+		return c < 0
+	}
+	return c > 0
+}
+
+// up moves the element at position i towards the root to its place
+func (h *MinMax[T]) up(i int) {
+	if i == 0 {
+		return
+	}
+
+	minLevel := isMinLevel(i)
+	parent := (i - 1) / 2
+
+	// An element that belongs on the opposite kind of level first swaps with
+	// its parent, then continues among the levels of the parent's kind
+	if h.less(parent, i, minLevel) {
+		h.items[i], h.items[parent] = h.items[parent], h.items[i]
+		i, minLevel = parent, !minLevel
+	}
+
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !h.less(i, grandparent, minLevel) {
+			return
+		}
+		h.items[i], h.items[grandparent] = h.items[grandparent], h.items[i]
+		i = grandparent
+	}
+}
+
+// down moves the element at position i towards the leaves to its place
+func (h *MinMax[T]) down(i int) {
+	minLevel := isMinLevel(i)
+	n := len(h.items)
+
+	for {
+		first := 2*i + 1
+		if first >= n {
+			return
+		}
+
+		// Find the best of the children and grandchildren of i
+		best := first
+		if first+1 < n && h.less(first+1, best, minLevel) {
+			best = first + 1
+		}
+		for gc := 2*first + 1; gc < n && gc <= 2*first+4; gc++ {
+			if h.less(gc, best, minLevel) {
+				best = gc
+			}
+		}
+
+		if !h.less(best, i, minLevel) {
+			return
+		}
+		h.items[i], h.items[best] = h.items[best], h.items[i]
+
+		if best <= first+1 {
+			// Children are on the opposite kind of level, so the walk ends here
+			return
+		}
+
+		// The element moved down two levels and may now be out of order with
+		// its new parent, which is on the opposite kind of level
+		if parent := (best - 1) / 2; h.less(parent, best, minLevel) {
+			h.items[best], h.items[parent] = h.items[parent], h.items[best]
+		}
+		i = best
+	}
+}
@@ -0,0 +1,146 @@
+package pqueue
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinMax_Empty(t *testing.T) {
+	h := NewMinMax[int](cmp.Compare[int])
+	assert.True(t, h.IsEmpty())
+	assert.Equal(t, 0, h.Cap())
+
+	_, err := h.PeekMin()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+	_, err = h.PeekMax()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+	_, err = h.PopMin()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+	_, err = h.PopMax()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+}
+
+func TestMinMax_PushPop(t *testing.T) {
+	h := NewMinMax[int](cmp.Compare[int])
+	assert.Nil(t, h.PushValue(5, 1, 9, 3, 7))
+	assert.Equal(t, 5, h.Len())
+
+	minVal, err := h.PeekMin()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, minVal)
+
+	maxVal, err := h.PeekMax()
+	assert.NoError(t, err)
+	assert.Equal(t, 9, maxVal)
+
+	result := []int{}
+	for i := 0; !h.IsEmpty(); i++ {
+		var val int
+		if i%2 == 0 {
+			val, err = h.PopMin()
+		} else {
+			val, err = h.PopMax()
+		}
+		assert.NoError(t, err)
+		result = append(result, val)
+	}
+	assert.Equal(t, []int{1, 9, 3, 7, 5}, result)
+}
+
+func TestMinMax_Randomized(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for round := 0; round < 50; round++ {
+		h := NewMinMax[int](cmp.Compare[int])
+		var ref []int
+
+		for op := 0; op < 500; op++ {
+			switch {
+			case len(ref) == 0 || rng.Intn(3) > 0:
+				v := rng.Intn(100)
+				h.PushValue(v)
+				ref = append(ref, v)
+				slices.Sort(ref)
+			case rng.Intn(2) == 0:
+				val, err := h.PopMin()
+				assert.NoError(t, err)
+				assert.Equal(t, ref[0], val)
+				ref = ref[1:]
+			default:
+				val, err := h.PopMax()
+				assert.NoError(t, err)
+				assert.Equal(t, ref[len(ref)-1], val)
+				ref = ref[:len(ref)-1]
+			}
+
+			if !assert.Equal(t, len(ref), h.Len()) {
+				return
+			}
+			if len(ref) > 0 {
+				minVal, _ := h.PeekMin()
+				maxVal, _ := h.PeekMax()
+				assert.Equal(t, ref[0], minVal)
+				assert.Equal(t, ref[len(ref)-1], maxVal)
+			}
+		}
+	}
+}
+
+func TestMinMax_Bounded(t *testing.T) {
+	t.Run("evicts the maximum", func(t *testing.T) {
+		h := NewBoundedMinMax[int](cmp.Compare[int], 3)
+		assert.Equal(t, 3, h.Cap())
+
+		assert.Nil(t, h.PushValue(5, 3, 8))
+		assert.Equal(t, []int{8}, h.PushValue(1))
+		assert.Equal(t, []int{9}, h.PushValue(9)) // worse than everything kept
+		assert.Equal(t, []int{5, 3}, h.PushValue(2, 0))
+		assert.Equal(t, 3, h.Len())
+
+		result := []int{}
+		for !h.IsEmpty() {
+			val, _ := h.PopMin()
+			result = append(result, val)
+		}
+		assert.Equal(t, []int{0, 1, 2}, result)
+	})
+
+	t.Run("top-k largest", func(t *testing.T) {
+		h := NewBoundedMinMax(func(a, b int) int { return cmp.Compare(b, a) }, 3)
+		for _, v := range []int{4, 10, 1, 7, 3, 9, 2} {
+			h.PushValue(v)
+		}
+
+		result := []int{}
+		for !h.IsEmpty() {
+			val, _ := h.PopMin()
+			result = append(result, val)
+		}
+		assert.Equal(t, []int{10, 9, 7}, result)
+	})
+
+	t.Run("invalid capacity", func(t *testing.T) {
+		assert.Panics(t, func() { NewBoundedMinMax[int](cmp.Compare[int], 0) })
+	})
+}
+
+func TestMinMax_Clear(t *testing.T) {
+	h := NewMinMax[int](cmp.Compare[int])
+	h.PushValue(1, 2, 3)
+	assert.Equal(t, 3, h.Clear())
+	assert.True(t, h.IsEmpty())
+}
+
+func BenchmarkMinMaxBoundedPush(b *testing.B) {
+	h := NewBoundedMinMax[int](cmp.Compare[int], 1024)
+	rng := rand.New(rand.NewSource(1))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h.PushValue(rng.Int())
+	}
+}
@@ -0,0 +1,279 @@
+// Package pqueue provides generic priority queues built on a binary heap.
+//
+// The order of the elements is given by a comparator with the same contract as
+// cmp.Compare: it returns a negative number when a must come out before b, zero
+// when they are equivalent and a positive number otherwise. Passing cmp.Compare
+// gives a min-queue; swapping its arguments gives a max-queue.
+//
+// PriorityQueue is not synchronized. SyncQueue wraps it behind a sync.RWMutex
+// and adds a blocking PopWait, and DelayQueue releases elements only once
+// their scheduled time has come. MinMax is a double-ended priority queue that
+// gives access to both the first and the last element of the order.
+//
+// Example usage:
+//
+//	pq := pqueue.New[int](cmp.Compare[int])
+//	pq.Push(3)
+//	h := pq.Push(5)
+//	pq.Update(h, 1)
+//	val, err := pq.Pop()  // returns 1, nil
+package pqueue
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"github.com/Pshimaf-Git/container/deque"
+)
+
+var (
+	// ErrEmptyQueue is shared with the deque package, so errors.Is works
+	// the same whichever container produced the error
+	ErrEmptyQueue    = deque.ErrEmptyQueue
+	ErrInvalidHandle = errors.New("handle does not belong to the queue")
+)
+
+// Handle refers to an element pushed to a PriorityQueue. It stays valid until
+// the element leaves the queue and can be used to change or remove the element
+// in O(log n)
+type Handle[T any] struct {
+	value T
+	index int // position in the heap, -1 once the element has left the queue
+	owner *PriorityQueue[T]
+}
+
+// Value returns the element the handle refers to
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// PriorityQueue is a binary heap ordered by a comparator.
+// It is not safe for concurrent use
+type PriorityQueue[T any] struct {
+	items []*Handle[T]
+	cmp   func(a, b T) int
+}
+
+// New creates and returns a new empty PriorityQueue ordered by cmp
+func New[T any](cmp func(a, b T) int) *PriorityQueue[T] {
+	return &PriorityQueue[T]{cmp: cmp}
+}
+
+// zeroval returns the zero value for type T
+func zeroval[T any]() T {
+	var zero T
+	return zero
+}
+
+// Len returns the number of elements in the queue
+func (pq *PriorityQueue[T]) Len() int {
+	return len(pq.items)
+}
+
+// IsEmpty returns true if the queue contains no elements
+func (pq *PriorityQueue[T]) IsEmpty() bool {
+	return len(pq.items) == 0
+}
+
+// Push adds a value to the queue and returns a handle to it
+func (pq *PriorityQueue[T]) Push(value T) *Handle[T] {
+	h := &Handle[T]{value: value, index: len(pq.items), owner: pq}
+	pq.items = append(pq.items, h)
+	pq.up(h.index)
+	return h
+}
+
+// Peek returns the element that Pop would return without removing it.
+// Returns an error if the queue is empty
+func (pq *PriorityQueue[T]) Peek() (T, error) {
+	const fancName = "(*PriorityQueue[T]).Peek"
+
+	if len(pq.items) == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return pq.items[0].value, nil
+}
+
+// Pop removes and returns the element that comes first in the order of the
+// comparator. Returns an error if the queue is empty
+func (pq *PriorityQueue[T]) Pop() (T, error) {
+	const fancName = "(*PriorityQueue[T]).Pop"
+
+	if len(pq.items) == 0 {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return pq.removeAt(0), nil
+}
+
+// Update replaces the element referred to by h with value and restores the
+// heap order. Returns an error if h is not in the queue
+func (pq *PriorityQueue[T]) Update(h *Handle[T], value T) error {
+	const fancName = "(*PriorityQueue[T]).Update"
+
+	if !pq.owns(h) {
+		return fmt.Errorf("%s: %w", fancName, ErrInvalidHandle)
+	}
+
+	h.value = value
+	pq.fix(h.index)
+	return nil
+}
+
+// Fix restores the heap order after the priority of the element referred to
+// by h has changed, for elements whose ordering depends on state outside of
+// the queue (e.g. pointers). Returns an error if h is not in the queue
+func (pq *PriorityQueue[T]) Fix(h *Handle[T]) error {
+	const fancName = "(*PriorityQueue[T]).Fix"
+
+	if !pq.owns(h) {
+		return fmt.Errorf("%s: %w", fancName, ErrInvalidHandle)
+	}
+
+	pq.fix(h.index)
+	return nil
+}
+
+// Remove removes the element referred to by h from the queue and returns it.
+// Returns an error if h is not in the queue
+func (pq *PriorityQueue[T]) Remove(h *Handle[T]) (T, error) {
+	const fancName = "(*PriorityQueue[T]).Remove"
+
+	if !pq.owns(h) {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrInvalidHandle)
+	}
+	return pq.removeAt(h.index), nil
+}
+
+// Clear removes all elements from the queue and returns the count of
+// elements that were removed. Handles to them become invalid
+func (pq *PriorityQueue[T]) Clear() int {
+	cleared := len(pq.items)
+	for _, h := range pq.items {
+		h.index = -1
+	}
+	pq.items = nil
+	return cleared
+}
+
+// Iterator returns an iterator that yields the elements in the order Pop
+// would return them, without removing them. The index is the position in
+// that order. Only the yielded elements are ordered, so stopping early
+// costs O(n + k log n) for k elements.
+// The queue must not be modified during the iteration
+func (pq *PriorityQueue[T]) Iterator() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		if len(pq.items) == 0 {
+			return
+		}
+
+		// Pop from a shallow copy of the heap; the handles are shared, so
+		// their indices must not be touched
+		heap := make([]T, len(pq.items))
+		for i, h := range pq.items {
+			heap[i] = h.value
+		}
+
+		for i := 0; len(heap) > 0; i++ {
+			if !yield(i, heap[0]) {
+				return
+			}
+
+			last := len(heap) - 1
+			heap[0] = heap[last]
+			heap = heap[:last]
+			siftDown(heap, 0, pq.cmp)
+		}
+	}
+}
+
+// owns reports whether h refers to an element currently in the queue
+func (pq *PriorityQueue[T]) owns(h *Handle[T]) bool {
+	return h != nil && h.owner == pq && h.index >= 0
+}
+
+// removeAt removes the element at position i of the heap and returns it
+func (pq *PriorityQueue[T]) removeAt(i int) T {
+	h := pq.items[i]
+	last := len(pq.items) - 1
+
+	if i != last {
+		pq.swap(i, last)
+	}
+	pq.items[last] = nil
+	pq.items = pq.items[:last]
+	h.index = -1
+
+	if i != last {
+		pq.fix(i)
+	}
+	return h.value
+}
+
+// fix moves the element at position i up or down to its place
+func (pq *PriorityQueue[T]) fix(i int) {
+	if !pq.down(i) {
+		pq.up(i)
+	}
+}
+
+// up moves the element at position i towards the root while it comes
+// before its parent
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if pq.cmp(pq.items[i].value, pq.items[parent].value) >= 0 {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down moves the element at position i towards the leaves while one of its
+// children comes before it. It reports whether the element moved
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.items)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			break
+		}
+		if right := child + 1; right < n && pq.cmp(pq.items[right].value, pq.items[child].value) < 0 {
+			child = right
+		}
+		if pq.cmp(pq.items[child].value, pq.items[i].value) >= 0 {
+			break
+		}
+		pq.swap(i, child)
+		i = child
+	}
+	return i > start
+}
+
+// swap exchanges the elements at positions i and j and their handle indices
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].index = i
+	pq.items[j].index = j
+}
+
+// siftDown moves heap[i] towards the leaves of a plain value heap
+func siftDown[T any](heap []T, i int, cmp func(a, b T) int) {
+	n := len(heap)
+	for {
+		child := 2*i + 1
+		if child >= n {
+			return
+		}
+		if right := child + 1; right < n && cmp(heap[right], heap[child]) < 0 {
+			child = right
+		}
+		if cmp(heap[child], heap[i]) >= 0 {
+			return
+		}
+		heap[i], heap[child] = heap[child], heap[i]
+		i = child
+	}
+}
@@ -0,0 +1,267 @@
+package pqueue
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNew(t *testing.T) {
+	pq := New[int](cmp.Compare[int])
+	assert.NotNil(t, pq)
+	assert.Equal(t, 0, pq.Len())
+	assert.True(t, pq.IsEmpty())
+}
+
+func TestPushPop(t *testing.T) {
+	tests := []struct {
+		name     string
+		cmp      func(a, b int) int
+		input    []int
+		expected []int
+	}{
+		{"min queue", cmp.Compare[int], []int{5, 1, 4, 2, 3}, []int{1, 2, 3, 4, 5}},
+		{"max queue", func(a, b int) int { return cmp.Compare(b, a) }, []int{5, 1, 4, 2, 3}, []int{5, 4, 3, 2, 1}},
+		{"duplicates", cmp.Compare[int], []int{2, 1, 2, 1}, []int{1, 1, 2, 2}},
+		{"empty", cmp.Compare[int], []int{}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pq := New(tt.cmp)
+			for _, v := range tt.input {
+				pq.Push(v)
+			}
+			assert.Equal(t, len(tt.input), pq.Len())
+
+			result := []int{}
+			for !pq.IsEmpty() {
+				val, err := pq.Pop()
+				assert.NoError(t, err)
+				result = append(result, val)
+			}
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestPeek(t *testing.T) {
+	pq := New[int](cmp.Compare[int])
+	_, err := pq.Peek()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+
+	pq.Push(3)
+	pq.Push(1)
+	val, err := pq.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 2, pq.Len()) // Shouldn't remove the element
+}
+
+func TestPopEmpty(t *testing.T) {
+	pq := New[string](cmp.Compare[string])
+	val, err := pq.Pop()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+	assert.Equal(t, "", val)
+}
+
+func TestUpdate(t *testing.T) {
+	pq := New[int](cmp.Compare[int])
+	pq.Push(1)
+	h := pq.Push(5)
+	pq.Push(3)
+
+	assert.NoError(t, pq.Update(h, 0))
+	assert.Equal(t, 0, h.Value())
+
+	val, _ := pq.Pop()
+	assert.Equal(t, 0, val)
+
+	h = pq.Push(2)
+	assert.NoError(t, pq.Update(h, 10))
+	assert.Equal(t, []int{1, 3, 10}, popAll(pq))
+}
+
+func TestFix(t *testing.T) {
+	type task struct {
+		name     string
+		priority int
+	}
+
+	pq := New(func(a, b *task) int { return cmp.Compare(a.priority, b.priority) })
+	a := &task{"a", 1}
+	b := &task{"b", 2}
+	c := &task{"c", 3}
+	pq.Push(a)
+	pq.Push(b)
+	hc := pq.Push(c)
+
+	c.priority = 0
+	assert.NoError(t, pq.Fix(hc))
+
+	val, err := pq.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, "c", val.name)
+}
+
+func TestRemove(t *testing.T) {
+	pq := New[int](cmp.Compare[int])
+	handles := make([]*Handle[int], 0, 6)
+	for _, v := range []int{4, 1, 6, 3, 5, 2} {
+		handles = append(handles, pq.Push(v))
+	}
+
+	val, err := pq.Remove(handles[3]) // 3
+	assert.NoError(t, err)
+	assert.Equal(t, 3, val)
+
+	val, err = pq.Remove(handles[1]) // 1, the root
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	assert.Equal(t, []int{2, 4, 5, 6}, popAll(pq))
+}
+
+func TestInvalidHandle(t *testing.T) {
+	pq := New[int](cmp.Compare[int])
+	other := New[int](cmp.Compare[int])
+
+	h := pq.Push(1)
+	foreign := other.Push(2)
+
+	tests := []struct {
+		name   string
+		handle *Handle[int]
+	}{
+		{"nil handle", nil},
+		{"handle of another queue", foreign},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, pq.Update(tt.handle, 0), ErrInvalidHandle)
+			assert.ErrorIs(t, pq.Fix(tt.handle), ErrInvalidHandle)
+			_, err := pq.Remove(tt.handle)
+			assert.ErrorIs(t, err, ErrInvalidHandle)
+		})
+	}
+
+	t.Run("removed handle", func(t *testing.T) {
+		_, err := pq.Pop()
+		assert.NoError(t, err)
+
+		assert.ErrorIs(t, pq.Update(h, 0), ErrInvalidHandle)
+		_, err = pq.Remove(h)
+		assert.ErrorIs(t, err, ErrInvalidHandle)
+	})
+
+	t.Run("cleared handle", func(t *testing.T) {
+		assert.Equal(t, 1, other.Clear())
+		assert.True(t, other.IsEmpty())
+		assert.ErrorIs(t, other.Fix(foreign), ErrInvalidHandle)
+	})
+}
+
+func TestIterator(t *testing.T) {
+	type pair struct {
+		i int
+		v int
+	}
+
+	pq := New[int](cmp.Compare[int])
+	for _, v := range []int{3, 1, 2} {
+		pq.Push(v)
+	}
+
+	result := []pair{}
+	for i, v := range pq.Iterator() {
+		result = append(result, pair{i, v})
+	}
+	assert.Equal(t, []pair{{0, 1}, {1, 2}, {2, 3}}, result)
+	assert.Equal(t, 3, pq.Len()) // Shouldn't remove the elements
+
+	// Early break
+	result = []pair{}
+	for i, v := range pq.Iterator() {
+		result = append(result, pair{i, v})
+		break
+	}
+	assert.Equal(t, []pair{{0, 1}}, result)
+
+	// Handles stay usable after iterating
+	h := pq.Push(0)
+	for range pq.Iterator() {
+	}
+	val, err := pq.Remove(h)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, val)
+
+	for range New[int](cmp.Compare[int]).Iterator() {
+		t.Fatal("iterator over empty queue yielded a value")
+	}
+}
+
+func TestRandomized(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	pq := New[int](cmp.Compare[int])
+	var handles []*Handle[int]
+	var expected []int
+
+	for i := 0; i < 2000; i++ {
+		switch op := r.Intn(4); {
+		case op < 2 || len(handles) == 0:
+			v := r.Intn(1000)
+			handles = append(handles, pq.Push(v))
+			expected = append(expected, v)
+		case op == 2:
+			k := r.Intn(len(handles))
+			v := r.Intn(1000)
+			old := handles[k].Value()
+			assert.NoError(t, pq.Update(handles[k], v))
+			expected[slices.Index(expected, old)] = v
+		default:
+			k := r.Intn(len(handles))
+			val, err := pq.Remove(handles[k])
+			assert.NoError(t, err)
+			expected = slices.Delete(expected, slices.Index(expected, val), slices.Index(expected, val)+1)
+			handles = slices.Delete(handles, k, k+1)
+		}
+	}
+
+	slices.Sort(expected)
+	assert.Equal(t, expected, popAll(pq))
+}
+
+// popAll pops every element of pq in order
+func popAll[T any](pq *PriorityQueue[T]) []T {
+	result := []T{}
+	for !pq.IsEmpty() {
+		val, _ := pq.Pop()
+		result = append(result, val)
+	}
+	return result
+}
+
+func BenchmarkPush(b *testing.B) {
+	pq := New[int](cmp.Compare[int])
+	r := rand.New(rand.NewSource(1))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq.Push(r.Int())
+	}
+}
+
+func BenchmarkPop(b *testing.B) {
+	pq := New[int](cmp.Compare[int])
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < b.N; i++ {
+		pq.Push(r.Int())
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = pq.Pop()
+	}
+}
@@ -0,0 +1,139 @@
+package pqueue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/Pshimaf-Git/container/deque"
+)
+
+// ErrClosed is shared with the deque package, so errors.Is works the same
+// whichever container produced the error
+var ErrClosed = deque.ErrClosed
+
+// SyncQueue is a thread-safe priority queue guarded by a sync.RWMutex.
+// Besides the non-blocking Pop it offers PopWait, which blocks until an
+// element is available
+type SyncQueue[T any] struct {
+	pq       *PriorityQueue[T]
+	mu       sync.RWMutex
+	notEmpty *sync.Cond // tied to mu
+	closed   bool
+}
+
+// NewSync creates and returns a new empty SyncQueue ordered by cmp
+func NewSync[T any](cmp func(a, b T) int) *SyncQueue[T] {
+	q := &SyncQueue[T]{pq: New(cmp)}
+	q.notEmpty = sync.NewCond(&q.mu)
+	return q
+}
+
+// Len returns the number of elements in the queue
+func (q *SyncQueue[T]) Len() int {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.pq.Len()
+}
+
+// IsEmpty returns true if the queue contains no elements
+func (q *SyncQueue[T]) IsEmpty() bool {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+
+	return q.pq.IsEmpty()
+}
+
+// Push adds one or more values to the queue.
+// Returns ErrClosed if the queue has been closed
+func (q *SyncQueue[T]) Push(values ...T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	const fancName = "(*SyncQueue[T]).Push"
+
+	if q.closed {
+		return fmt.Errorf("%s: %w", fancName, ErrClosed)
+	}
+
+	for _, v := range values {
+		q.pq.Push(v)
+		q.notEmpty.Signal()
+	}
+	return nil
+}
+
+// Peek returns the highest-priority element without removing it.
+// Returns ErrEmptyQueue if the queue is empty
+func (q *SyncQueue[T]) Peek() (T, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	const fancName = "(*SyncQueue[T]).Peek"
+
+	if q.pq.IsEmpty() {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return q.pq.items[0].value, nil
+}
+
+// Pop removes and returns the highest-priority element.
+// Returns ErrEmptyQueue if the queue is empty, or ErrClosed if it is empty
+// and closed
+func (q *SyncQueue[T]) Pop() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	const fancName = "(*SyncQueue[T]).Pop"
+
+	if q.pq.IsEmpty() {
+		if q.closed {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrClosed)
+		}
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return q.pq.removeAt(0), nil
+}
+
+// PopWait removes and returns the highest-priority element, blocking until
+// one is available.
+// Returns an error wrapping ctx.Err() if the context is done first, or
+// ErrClosed if the queue is closed and has no elements left
+func (q *SyncQueue[T]) PopWait(ctx context.Context) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	const fancName = "(*SyncQueue[T]).PopWait"
+
+	stop := context.AfterFunc(ctx, q.broadcast)
+	defer stop()
+
+	for q.pq.IsEmpty() {
+		if q.closed {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrClosed)
+		}
+		if err := ctx.Err(); err != nil {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, err)
+		}
+		q.notEmpty.Wait()
+	}
+
+	return q.pq.removeAt(0), nil
+}
+
+// Close marks the queue as closed and wakes every goroutine blocked in
+// PopWait. After Close every push fails with ErrClosed, while pops keep
+// returning the remaining elements and then fail with ErrClosed.
+// Calling Close more than once has no effect
+func (q *SyncQueue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.notEmpty.Broadcast()
+}
+
+// broadcast wakes every waiter so that it re-checks its condition
+func (q *SyncQueue[T]) broadcast() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.notEmpty.Broadcast()
+}
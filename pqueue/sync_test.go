@@ -0,0 +1,157 @@
+package pqueue
+
+import (
+	"cmp"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncQueue_PushPop(t *testing.T) {
+	q := NewSync[int](cmp.Compare[int])
+	assert.True(t, q.IsEmpty())
+
+	_, err := q.Pop()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+	_, err = q.Peek()
+	assert.ErrorIs(t, err, ErrEmptyQueue)
+
+	assert.NoError(t, q.Push(3, 1, 2))
+	assert.Equal(t, 3, q.Len())
+
+	val, err := q.Peek()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	for _, want := range []int{1, 2, 3} {
+		val, err := q.Pop()
+		assert.NoError(t, err)
+		assert.Equal(t, want, val)
+	}
+}
+
+func TestSyncQueue_PopWait(t *testing.T) {
+	t.Run("blocks until element is pushed", func(t *testing.T) {
+		q := NewSync[int](cmp.Compare[int])
+		result := make(chan int)
+
+		go func() {
+			val, err := q.PopWait(context.Background())
+			assert.NoError(t, err)
+			result <- val
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		assert.NoError(t, q.Push(7))
+
+		select {
+		case val := <-result:
+			assert.Equal(t, 7, val)
+		case <-time.After(time.Second):
+			t.Fatal("PopWait did not return after Push")
+		}
+	})
+
+	t.Run("returns highest priority element", func(t *testing.T) {
+		q := NewSync[int](cmp.Compare[int])
+		assert.NoError(t, q.Push(5, 2, 8))
+
+		val, err := q.PopWait(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, 2, val)
+	})
+
+	t.Run("context cancelled", func(t *testing.T) {
+		q := NewSync[int](cmp.Compare[int])
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := q.PopWait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("released by close", func(t *testing.T) {
+		q := NewSync[int](cmp.Compare[int])
+		done := make(chan error)
+
+		go func() {
+			_, err := q.PopWait(context.Background())
+			done <- err
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		q.Close()
+
+		select {
+		case err := <-done:
+			assert.ErrorIs(t, err, ErrClosed)
+		case <-time.After(time.Second):
+			t.Fatal("PopWait was not released by Close")
+		}
+	})
+}
+
+func TestSyncQueue_Close(t *testing.T) {
+	q := NewSync[int](cmp.Compare[int])
+	assert.NoError(t, q.Push(1))
+	q.Close()
+	q.Close() // must be a no-op
+
+	assert.ErrorIs(t, q.Push(2), ErrClosed)
+
+	val, err := q.Pop()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	_, err = q.Pop()
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func TestSyncQueue_Concurrent(t *testing.T) {
+	q := NewSync[int](cmp.Compare[int])
+	const producers = 4
+	const perProducer = 1000
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				assert.NoError(t, q.Push(p*perProducer+i))
+			}
+		}(p)
+	}
+
+	seen := make([]bool, producers*perProducer)
+	var mu sync.Mutex
+	var consumers sync.WaitGroup
+	for c := 0; c < 4; c++ {
+		consumers.Add(1)
+		go func() {
+			defer consumers.Done()
+			for {
+				val, err := q.PopWait(context.Background())
+				if err != nil {
+					assert.ErrorIs(t, err, ErrClosed)
+					return
+				}
+				mu.Lock()
+				assert.False(t, seen[val], "value %d received twice", val)
+				seen[val] = true
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+	q.Close()
+	consumers.Wait()
+
+	for i, ok := range seen {
+		assert.True(t, ok, "value %d was lost", i)
+	}
+}
@@ -0,0 +1,135 @@
+// Package queue provides lock-free, thread-safe generic FIFO queues.
+//
+// Queue is a Michael–Scott queue: an unbounded linked list with a sentinel
+// node whose head and tail are advanced with atomic compare-and-swap (CAS)
+// operations, in the same spirit as stack.Stack. Goroutines that find the
+// tail lagging behind help to advance it, so no operation waits on another.
+//
+// Ring is a bounded, allocation-free multi-producer/multi-consumer queue with
+// per-slot sequence numbers, for pipelines that need a fixed memory footprint.
+// SPSC is a wait-free ring for the common case of exactly one producer and one
+// consumer, which needs no CAS at all.
+//
+// Example usage:
+//
+//	q := queue.New[int]()
+//	q.Enqueue(1)
+//	q.Enqueue(2)
+//	val, ok := q.Dequeue()  // returns 1, true
+//	val, ok = q.Dequeue()   // returns 2, true
+//	val, ok = q.Dequeue()   // returns 0, false (empty queue)
+package queue
+
+import (
+	"sync/atomic"
+)
+
+// node represents a single element in the queue. The first node reachable
+// from head is a sentinel whose value has already been dequeued
+type node[T any] struct {
+	value T
+	next  atomic.Pointer[node[T]]
+}
+
+// Queue is a thread-safe, generic FIFO (First-In-First-Out) data structure
+// implemented using atomic operations.
+// It supports concurrent enqueue and dequeue operations without locks.
+// A Queue must be created with New
+type Queue[T any] struct {
+	head atomic.Pointer[node[T]]
+	tail atomic.Pointer[node[T]]
+	len  atomic.Int64
+}
+
+// New creates and returns a new, empty Queue for type T.
+// Both head and tail point to the same sentinel node
+func New[T any]() *Queue[T] {
+	q := &Queue[T]{}
+	sentinel := &node[T]{}
+	q.head.Store(sentinel)
+	q.tail.Store(sentinel)
+	return q
+}
+
+// Len returns the number of elements in the queue.
+// The counter is updated right after an element is linked or unlinked, so
+// under concurrent use it may briefly lag behind the contents
+func (q *Queue[T]) Len() int {
+	return int(max(q.len.Load(), 0))
+}
+
+// Empty reports whether the queue has no elements
+func (q *Queue[T]) Empty() bool {
+	return q.head.Load().next.Load() == nil
+}
+
+// Enqueue adds a value to the back of the queue.
+// This operation is atomic and thread-safe, using compare-and-swap (CAS) to
+// handle concurrent access
+func (q *Queue[T]) Enqueue(value T) {
+	n := &node[T]{value: value}
+
+	for {
+		tail := q.tail.Load()
+		next := tail.next.Load()
+
+		if tail != q.tail.Load() {
+			continue
+		}
+
+		if next != nil {
+			// The tail is lagging behind, help to advance it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		if tail.next.CompareAndSwap(nil, n) {
+			q.tail.CompareAndSwap(tail, n)
+			q.len.Add(1)
+			return
+		}
+	}
+}
+
+// Dequeue removes and returns the value at the front of the queue.
+// It returns the value and a boolean indicating whether the operation was successful.
+// If the queue is empty, it returns the zero value of type T and false.
+// This operation is atomic and thread-safe, using compare-and-swap (CAS) to
+// handle concurrent access
+func (q *Queue[T]) Dequeue() (T, bool) {
+	for {
+		head := q.head.Load()
+		tail := q.tail.Load()
+		next := head.next.Load()
+
+		if head != q.head.Load() {
+			continue
+		}
+
+		if next == nil {
+			return zeroval[T](), false
+		}
+
+		if head == tail {
+			// The tail is lagging behind, help to advance it
+			q.tail.CompareAndSwap(tail, next)
+			continue
+		}
+
+		// next becomes the new sentinel, and only the winner of the CAS
+		// reads its value
+		if q.head.CompareAndSwap(head, next) {
+			q.len.Add(-1)
+			val := next.value
+			next.value = zeroval[T]() // allow GC of the removed value
+			return val, true
+		}
+	}
+}
+
+// zeroval returns the zero value for type T.
+// This is used to return a valid value when dequeuing from an empty queue
+func zeroval[T any]() T {
+	var z T
+	return z
+}
@@ -0,0 +1,243 @@
+package queue
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pshimaf-Git/container/deque"
+)
+
+func TestNew(t *testing.T) {
+	q := New[int]()
+	if q == nil {
+		t.Fatal("New() returned nil")
+	}
+	if !q.Empty() {
+		t.Error("Empty() = false, want true")
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", q.Len())
+	}
+}
+
+func TestEnqueueDequeue(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		dequeues int
+		expected []int
+		wantLen  int
+	}{
+		{"Dequeue from empty queue", nil, 1, nil, 0},
+		{"Enqueue one, dequeue one", []int{1}, 1, []int{1}, 0},
+		{"FIFO order", []int{1, 2, 3}, 3, []int{1, 2, 3}, 0},
+		{"Partial dequeue", []int{1, 2, 3}, 2, []int{1, 2}, 1},
+		{"Dequeue more than enqueued", []int{1, 2}, 3, []int{1, 2}, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := New[int]()
+			for _, v := range tc.values {
+				q.Enqueue(v)
+			}
+
+			var got []int
+			for i := 0; i < tc.dequeues; i++ {
+				if v, ok := q.Dequeue(); ok {
+					got = append(got, v)
+				}
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) {
+				t.Errorf("dequeued %v, want %v", got, tc.expected)
+			}
+			if q.Len() != tc.wantLen {
+				t.Errorf("Len() = %d, want %d", q.Len(), tc.wantLen)
+			}
+			if q.Empty() != (tc.wantLen == 0) {
+				t.Errorf("Empty() = %t, want %t", q.Empty(), tc.wantLen == 0)
+			}
+		})
+	}
+
+	t.Run("Dequeue from empty queue returns zero value", func(t *testing.T) {
+		q := New[string]()
+		val, ok := q.Dequeue()
+		if ok || val != "" {
+			t.Errorf("Dequeue() = %q, %t, want \"\", false", val, ok)
+		}
+	})
+}
+
+func TestDequeue_ReleasesValue(t *testing.T) {
+	type payload struct{ buf [1 << 10]byte }
+
+	q := New[*payload]()
+	released := make(chan struct{})
+	p := new(payload)
+	runtime.AddCleanup(p, func(struct{}) { close(released) }, struct{}{})
+
+	q.Enqueue(p)
+	q.Dequeue()
+	p = nil
+
+	// The dequeued node stays in the queue as the sentinel, and must not
+	// keep the value reachable
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+		select {
+		case <-released:
+			if !q.Empty() {
+				t.Error("Empty() = false, want true")
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Error("dequeued value is still reachable from the queue")
+}
+
+func TestConcurrentEnqueueDequeue(t *testing.T) {
+	q := New[int]()
+	const numProducers = 8
+	const numConsumers = 8
+	const perProducer = 5000
+
+	var wg sync.WaitGroup
+	for p := 0; p < numProducers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perProducer; i++ {
+				q.Enqueue(p*perProducer + i)
+			}
+		}(p)
+	}
+
+	results := make([][]int, numConsumers)
+	var consumers sync.WaitGroup
+	var remaining sync.WaitGroup
+	remaining.Add(numProducers * perProducer)
+	done := make(chan struct{})
+	go func() {
+		remaining.Wait()
+		close(done)
+	}()
+
+	for c := 0; c < numConsumers; c++ {
+		consumers.Add(1)
+		go func(c int) {
+			defer consumers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				if v, ok := q.Dequeue(); ok {
+					results[c] = append(results[c], v)
+					remaining.Done()
+				}
+			}
+		}(c)
+	}
+
+	wg.Wait()
+	consumers.Wait()
+
+	seen := make([]bool, numProducers*perProducer)
+	for _, values := range results {
+		// FIFO: every consumer sees the values of each producer in order
+		last := make([]int, numProducers)
+		for i := range last {
+			last[i] = -1
+		}
+
+		for _, v := range values {
+			if seen[v] {
+				t.Fatalf("value %d dequeued twice", v)
+			}
+			seen[v] = true
+
+			p := v / perProducer
+			if v <= last[p] {
+				t.Fatalf("value %d of producer %d dequeued after %d", v, p, last[p])
+			}
+			last[p] = v
+		}
+	}
+
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("value %d was lost", v)
+		}
+	}
+	if !q.Empty() || q.Len() != 0 {
+		t.Errorf("Len() = %d, Empty() = %t, want 0, true", q.Len(), q.Empty())
+	}
+}
+
+func BenchmarkQueue_Enqueue(b *testing.B) {
+	q := New[int]()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		q.Enqueue(i)
+	}
+}
+
+func BenchmarkQueue_Dequeue(b *testing.B) {
+	q := New[int]()
+	for i := 0; i < b.N; i++ {
+		q.Enqueue(i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = q.Dequeue()
+	}
+}
+
+// BenchmarkFIFO compares Queue with deque.Deque used as a FIFO
+// (PushBack/PopFront) under parallel producers and consumers
+func BenchmarkFIFO(b *testing.B) {
+	cpuConfigs := []int{1, 2, 4, 8, runtime.NumCPU()}
+
+	for _, cpus := range cpuConfigs {
+		b.Run(fmt.Sprintf("Queue/cpus=%d", cpus), func(b *testing.B) {
+			runtime.GOMAXPROCS(cpus)
+			q := New[int]()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if i%2 == 0 {
+						q.Enqueue(i)
+					} else {
+						q.Dequeue()
+					}
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("Deque/cpus=%d", cpus), func(b *testing.B) {
+			runtime.GOMAXPROCS(cpus)
+			d := deque.New[int]()
+
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if i%2 == 0 {
+						_ = d.PushBack(i)
+					} else {
+						_, _ = d.PopFront()
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"github.com/Pshimaf-Git/container/deque"
+)
+
+// The error sentinels are shared with the deque package, so errors.Is works
+// the same whichever container produced the error
+var (
+	ErrEmptyQueue = deque.ErrEmptyQueue
+	ErrFullQueue  = deque.ErrFullQueue
+)
+
+// cacheLineSize is used to pad hot atomic fields so that producers and
+// consumers do not invalidate each other's cache lines
+const cacheLineSize = 64
+
+// slot is a cell of the ring. Its sequence number tells which lap of the
+// ring the cell is ready for and whether it holds a value
+type slot[T any] struct {
+	seq   atomic.Uint64
+	value T
+}
+
+// Ring is a fixed-capacity, multi-producer/multi-consumer FIFO queue based on
+// Dmitry Vyukov's bounded queue. Every slot carries a sequence number, so
+// producers and consumers only contend on their own position counter and no
+// operation allocates.
+// A Ring must be created with NewRing
+type Ring[T any] struct {
+	_      [cacheLineSize]byte
+	enqPos atomic.Uint64
+	_      [cacheLineSize - 8]byte
+	deqPos atomic.Uint64
+	_      [cacheLineSize - 8]byte
+	mask   uint64
+	slots  []slot[T]
+}
+
+// NewRing creates and returns a new, empty Ring that holds at least capacity
+// elements. The capacity is rounded up to a power of two, and to at least two
+// since with a single slot its "filled" and "free on the next lap" sequence
+// numbers would be the same.
+// It panics if capacity is not positive
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+
+	size := uint64(2)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+
+	r := &Ring[T]{
+		mask:  size - 1,
+		slots: make([]slot[T], size),
+	}
+	for i := range r.slots {
+		r.slots[i].seq.Store(uint64(i))
+	}
+	return r
+}
+
+// Cap returns the number of elements the ring can hold
+func (r *Ring[T]) Cap() int {
+	return len(r.slots)
+}
+
+// Len returns the number of elements in the ring.
+// Under concurrent use the result is only a snapshot
+func (r *Ring[T]) Len() int {
+	deq := r.deqPos.Load()
+	enq := r.enqPos.Load()
+	if enq <= deq {
+		return 0
+	}
+	return int(min(enq-deq, uint64(len(r.slots))))
+}
+
+// TryEnqueue adds a value to the back of the ring without blocking.
+// Returns ErrFullQueue if there is no free slot
+func (r *Ring[T]) TryEnqueue(value T) error {
+	const fancName = "(*Ring[T]).TryEnqueue"
+
+	if !r.enqueue(value) {
+		return fmt.Errorf("%s: %w", fancName, ErrFullQueue)
+	}
+	return nil
+}
+
+// TryDequeue removes and returns the value at the front of the ring without
+// blocking. Returns ErrEmptyQueue if the ring is empty
+func (r *Ring[T]) TryDequeue() (T, error) {
+	const fancName = "(*Ring[T]).TryDequeue"
+
+	value, ok := r.dequeue()
+	if !ok {
+		return zeroval[T](), fmt.Errorf("%s: %w", fancName, ErrEmptyQueue)
+	}
+	return value, nil
+}
+
+// EnqueueWait adds a value to the back of the ring, waiting for a free slot.
+// Returns an error wrapping ctx.Err() if the context is done first
+func (r *Ring[T]) EnqueueWait(ctx context.Context, value T) error {
+	const fancName = "(*Ring[T]).EnqueueWait"
+
+	for attempt := 0; !r.enqueue(value); attempt++ {
+		if err := backoff(ctx, attempt); err != nil {
+			return fmt.Errorf("%s: %w", fancName, err)
+		}
+	}
+	return nil
+}
+
+// DequeueWait removes and returns the value at the front of the ring, waiting
+// for one to arrive. Returns an error wrapping ctx.Err() if the context is
+// done first
+func (r *Ring[T]) DequeueWait(ctx context.Context) (T, error) {
+	const fancName = "(*Ring[T]).DequeueWait"
+
+	for attempt := 0; ; attempt++ {
+		if value, ok := r.dequeue(); ok {
+			return value, nil
+		}
+		if err := backoff(ctx, attempt); err != nil {
+			return zeroval[T](), fmt.Errorf("%s: %w", fancName, err)
+		}
+	}
+}
+
+// enqueue claims the slot at the enqueue position and fills it.
+// It reports false if the ring is full
+func (r *Ring[T]) enqueue(value T) bool {
+	pos := r.enqPos.Load()
+	for {
+		s := &r.slots[pos&r.mask]
+		seq := s.seq.Load()
+
+		switch diff := int64(seq - pos); {
+		case diff == 0:
+			// The slot is free on this lap, try to claim it
+			if r.enqPos.CompareAndSwap(pos, pos+1) {
+				s.value = value
+				s.seq.Store(pos + 1)
+				return true
+			}
+			pos = r.enqPos.Load()
+		case diff < 0:
+			// The slot still holds a value from the previous lap
+			return false
+		default:
+			// Another producer claimed the slot, catch up
+			pos = r.enqPos.Load()
+		}
+	}
+}
+
+// dequeue claims the slot at the dequeue position and empties it.
+// It reports false if the ring is empty
+func (r *Ring[T]) dequeue() (T, bool) {
+	pos := r.deqPos.Load()
+	for {
+		s := &r.slots[pos&r.mask]
+		seq := s.seq.Load()
+
+		switch diff := int64(seq - (pos + 1)); {
+		case diff == 0:
+			// The slot is filled on this lap, try to claim it
+			if r.deqPos.CompareAndSwap(pos, pos+1) {
+				value := s.value
+				s.value = zeroval[T]() // allow GC of the removed value
+				s.seq.Store(pos + r.mask + 1)
+				return value, true
+			}
+			pos = r.deqPos.Load()
+		case diff < 0:
+			// No producer has filled the slot yet
+			return zeroval[T](), false
+		default:
+			// Another consumer claimed the slot, catch up
+			pos = r.deqPos.Load()
+		}
+	}
+}
+
+// backoff waits before the next attempt of a blocking operation: it yields the
+// processor for the first attempts and then sleeps for a growing duration.
+// Returns ctx.Err() if the context is done
+func backoff(ctx context.Context, attempt int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	const spins = 16
+	if attempt < spins {
+		runtime.Gosched()
+		return nil
+	}
+
+	delay := time.Microsecond << min(attempt-spins, 10) // at most ~1ms
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
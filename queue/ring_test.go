@@ -0,0 +1,252 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Pshimaf-Git/container/deque"
+)
+
+func TestNewRing(t *testing.T) {
+	tests := []struct {
+		capacity int
+		wantCap  int
+	}{
+		{1, 2},
+		{2, 2},
+		{3, 4},
+		{8, 8},
+		{1000, 1024},
+	}
+
+	for _, tc := range tests {
+		t.Run(fmt.Sprintf("capacity=%d", tc.capacity), func(t *testing.T) {
+			r := NewRing[int](tc.capacity)
+			if r.Cap() != tc.wantCap {
+				t.Errorf("Cap() = %d, want %d", r.Cap(), tc.wantCap)
+			}
+			if r.Len() != 0 {
+				t.Errorf("Len() = %d, want 0", r.Len())
+			}
+		})
+	}
+
+	t.Run("Non-positive capacity panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("NewRing(0) did not panic")
+			}
+		}()
+		NewRing[int](0)
+	})
+}
+
+func TestRingTryEnqueueDequeue(t *testing.T) {
+	r := NewRing[int](4)
+
+	if _, err := r.TryDequeue(); !errors.Is(err, ErrEmptyQueue) {
+		t.Errorf("TryDequeue() error = %v, want %v", err, ErrEmptyQueue)
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := r.TryEnqueue(i); err != nil {
+			t.Fatalf("TryEnqueue(%d) error = %v", i, err)
+		}
+	}
+	if err := r.TryEnqueue(4); !errors.Is(err, ErrFullQueue) {
+		t.Errorf("TryEnqueue() error = %v, want %v", err, ErrFullQueue)
+	}
+	if r.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", r.Len())
+	}
+
+	// Wrap around the ring a few times
+	for lap := 0; lap < 3; lap++ {
+		for i := 0; i < 4; i++ {
+			val, err := r.TryDequeue()
+			if err != nil || val != lap*4+i {
+				t.Fatalf("TryDequeue() = %d, %v, want %d, nil", val, err, lap*4+i)
+			}
+			if err := r.TryEnqueue((lap+1)*4 + i); err != nil {
+				t.Fatalf("TryEnqueue() error = %v", err)
+			}
+		}
+	}
+}
+
+func TestRingSharesDequeErrors(t *testing.T) {
+	r := NewRing[int](1)
+	_, err := r.TryDequeue()
+	if !errors.Is(err, deque.ErrEmptyQueue) {
+		t.Errorf("TryDequeue() error = %v, want %v", err, deque.ErrEmptyQueue)
+	}
+}
+
+func TestRingWait(t *testing.T) {
+	t.Run("DequeueWait receives later value", func(t *testing.T) {
+		r := NewRing[int](2)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_ = r.TryEnqueue(42)
+		}()
+
+		val, err := r.DequeueWait(context.Background())
+		if err != nil || val != 42 {
+			t.Errorf("DequeueWait() = %d, %v, want 42, nil", val, err)
+		}
+	})
+
+	t.Run("EnqueueWait waits for free slot", func(t *testing.T) {
+		r := NewRing[int](2)
+		_ = r.TryEnqueue(1)
+		_ = r.TryEnqueue(2)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			_, _ = r.TryDequeue()
+		}()
+
+		if err := r.EnqueueWait(context.Background(), 3); err != nil {
+			t.Errorf("EnqueueWait() error = %v", err)
+		}
+		if val, _ := r.TryDequeue(); val != 2 {
+			t.Errorf("TryDequeue() = %d, want 2", val)
+		}
+		if val, _ := r.TryDequeue(); val != 3 {
+			t.Errorf("TryDequeue() = %d, want 3", val)
+		}
+	})
+
+	t.Run("Context cancellation", func(t *testing.T) {
+		r := NewRing[int](1)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, err := r.DequeueWait(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("DequeueWait() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		_ = r.TryEnqueue(1)
+		_ = r.TryEnqueue(2)
+		if err := r.EnqueueWait(ctx, 3); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("EnqueueWait() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+	})
+}
+
+func TestRingConcurrent(t *testing.T) {
+	r := NewRing[int](64)
+	const numProducers = 8
+	const numConsumers = 8
+	const perProducer = 5000
+
+	ctx := context.Background()
+	var producers sync.WaitGroup
+	for p := 0; p < numProducers; p++ {
+		producers.Add(1)
+		go func(p int) {
+			defer producers.Done()
+			for i := 0; i < perProducer; i++ {
+				if err := r.EnqueueWait(ctx, p*perProducer+i); err != nil {
+					t.Errorf("EnqueueWait() error = %v", err)
+					return
+				}
+			}
+		}(p)
+	}
+
+	results := make([][]int, numConsumers)
+	var consumers sync.WaitGroup
+	for c := 0; c < numConsumers; c++ {
+		consumers.Add(1)
+		go func(c int) {
+			defer consumers.Done()
+			for i := 0; i < perProducer*numProducers/numConsumers; i++ {
+				v, err := r.DequeueWait(ctx)
+				if err != nil {
+					t.Errorf("DequeueWait() error = %v", err)
+					return
+				}
+				results[c] = append(results[c], v)
+			}
+		}(c)
+	}
+
+	producers.Wait()
+	consumers.Wait()
+
+	seen := make([]bool, numProducers*perProducer)
+	for _, values := range results {
+		last := make([]int, numProducers)
+		for i := range last {
+			last[i] = -1
+		}
+		for _, v := range values {
+			if seen[v] {
+				t.Fatalf("value %d dequeued twice", v)
+			}
+			seen[v] = true
+
+			p := v / perProducer
+			if v <= last[p] {
+				t.Fatalf("value %d of producer %d dequeued after %d", v, p, last[p])
+			}
+			last[p] = v
+		}
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("value %d was lost", v)
+		}
+	}
+	if r.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", r.Len())
+	}
+}
+
+// BenchmarkBoundedFIFO compares Ring with a bounded deque.Deque used as a FIFO
+// under parallel producers and consumers
+func BenchmarkBoundedFIFO(b *testing.B) {
+	const capacity = 1024
+	cpuConfigs := []int{1, 2, 4, 8, runtime.NumCPU()}
+
+	for _, cpus := range cpuConfigs {
+		b.Run(fmt.Sprintf("Ring/cpus=%d", cpus), func(b *testing.B) {
+			runtime.GOMAXPROCS(cpus)
+			r := NewRing[int](capacity)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if i%2 == 0 {
+						_ = r.TryEnqueue(i)
+					} else {
+						_, _ = r.TryDequeue()
+					}
+				}
+			})
+		})
+
+		b.Run(fmt.Sprintf("Deque/cpus=%d", cpus), func(b *testing.B) {
+			runtime.GOMAXPROCS(cpus)
+			d := deque.NewBounded[int](capacity, deque.OverflowReject)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				for i := 0; pb.Next(); i++ {
+					if i%2 == 0 {
+						_ = d.PushBack(i)
+					} else {
+						_, _ = d.PopFront()
+					}
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package queue
+
+import (
+	"sync/atomic"
+)
+
+// SPSC is a fixed-capacity, wait-free ring buffer for exactly one producer and
+// one consumer goroutine. The producer only writes the tail and the consumer
+// only writes the head, each on its own cache line, and each side keeps a
+// cached copy of the other side's index so it only reloads it when the ring
+// looks full or empty.
+// Calling the write methods from more than one goroutine, or the read methods
+// from more than one goroutine, is not safe.
+// An SPSC must be created with NewSPSC
+type SPSC[T any] struct {
+	_ [cacheLineSize]byte
+
+	// Owned by the consumer
+	head       atomic.Uint64
+	cachedTail uint64
+	_          [cacheLineSize - 16]byte
+
+	// Owned by the producer
+	tail       atomic.Uint64
+	cachedHead uint64
+	_          [cacheLineSize - 16]byte
+
+	mask uint64
+	buf  []T
+}
+
+// NewSPSC creates and returns a new, empty SPSC ring that holds at least
+// capacity elements. The capacity is rounded up to a power of two.
+// It panics if capacity is not positive
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	if capacity <= 0 {
+		panic("queue: capacity must be positive")
+	}
+
+	size := uint64(1)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+
+	return &SPSC[T]{
+		mask: size - 1,
+		buf:  make([]T, size),
+	}
+}
+
+// Cap returns the number of elements the ring can hold
+func (q *SPSC[T]) Cap() int {
+	return len(q.buf)
+}
+
+// Len returns the number of elements in the ring.
+// Under concurrent use the result is only a snapshot
+func (q *SPSC[T]) Len() int {
+	head := q.head.Load()
+	tail := q.tail.Load()
+	if tail <= head {
+		return 0
+	}
+	return int(tail - head)
+}
+
+// Write adds a value to the back of the ring.
+// It reports false if the ring is full. Must only be called by the producer
+func (q *SPSC[T]) Write(value T) bool {
+	tail := q.tail.Load()
+	if q.free(tail, 1) == 0 {
+		return false
+	}
+
+	q.buf[tail&q.mask] = value
+	q.tail.Store(tail + 1)
+	return true
+}
+
+// WriteN adds as many of values as fit to the back of the ring, publishing
+// them all at once, and returns how many were written.
+// Must only be called by the producer
+func (q *SPSC[T]) WriteN(values ...T) int {
+	tail := q.tail.Load()
+	n := min(q.free(tail, uint64(len(values))), uint64(len(values)))
+	if n == 0 {
+		return 0
+	}
+
+	start := tail & q.mask
+	copied := copy(q.buf[start:], values[:n])
+	copy(q.buf, values[copied:n])
+
+	q.tail.Store(tail + n)
+	return int(n)
+}
+
+// Read removes and returns the value at the front of the ring.
+// It returns the zero value of type T and false if the ring is empty.
+// Must only be called by the consumer
+func (q *SPSC[T]) Read() (T, bool) {
+	head := q.head.Load()
+	if q.available(head, 1) == 0 {
+		return zeroval[T](), false
+	}
+
+	i := head & q.mask
+	value := q.buf[i]
+	q.buf[i] = zeroval[T]() // allow GC of the removed value
+	q.head.Store(head + 1)
+	return value, true
+}
+
+// ReadN removes up to len(dst) values from the front of the ring into dst and
+// returns how many were read. Must only be called by the consumer
+func (q *SPSC[T]) ReadN(dst []T) int {
+	head := q.head.Load()
+	n := min(q.available(head, uint64(len(dst))), uint64(len(dst)))
+	if n == 0 {
+		return 0
+	}
+
+	start := head & q.mask
+	end := min(start+n, uint64(len(q.buf)))
+	copied := copy(dst, q.buf[start:end])
+	clear(q.buf[start:end])
+
+	rest := n - uint64(copied)
+	copy(dst[copied:], q.buf[:rest])
+	clear(q.buf[:rest])
+
+	q.head.Store(head + n)
+	return int(n)
+}
+
+// free returns the number of free slots as seen by the producer, refreshing
+// the cached head only when the cached value shows fewer than want of them
+func (q *SPSC[T]) free(tail, want uint64) uint64 {
+	size := uint64(len(q.buf))
+	if size-(tail-q.cachedHead) < want {
+		q.cachedHead = q.head.Load()
+	}
+	return size - (tail - q.cachedHead)
+}
+
+// available returns the number of filled slots as seen by the consumer,
+// refreshing the cached tail only when the cached value shows fewer than want
+// of them
+func (q *SPSC[T]) available(head, want uint64) uint64 {
+	if q.cachedTail-head < want {
+		q.cachedTail = q.tail.Load()
+	}
+	return q.cachedTail - head
+}
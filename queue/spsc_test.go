@@ -0,0 +1,239 @@
+package queue
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/Pshimaf-Git/container/deque"
+	"github.com/Pshimaf-Git/container/stack"
+)
+
+func TestNewSPSC(t *testing.T) {
+	q := NewSPSC[int](5)
+	if q.Cap() != 8 {
+		t.Errorf("Cap() = %d, want 8", q.Cap())
+	}
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", q.Len())
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("NewSPSC(0) did not panic")
+		}
+	}()
+	NewSPSC[int](0)
+}
+
+func TestSPSCWriteRead(t *testing.T) {
+	q := NewSPSC[int](4)
+
+	if val, ok := q.Read(); ok || val != 0 {
+		t.Errorf("Read() = %d, %t, want 0, false", val, ok)
+	}
+
+	for i := 0; i < 4; i++ {
+		if !q.Write(i) {
+			t.Fatalf("Write(%d) = false, want true", i)
+		}
+	}
+	if q.Write(4) {
+		t.Error("Write() = true on a full ring")
+	}
+	if q.Len() != 4 {
+		t.Errorf("Len() = %d, want 4", q.Len())
+	}
+
+	// Wrap around the ring a few times
+	for lap := 0; lap < 3; lap++ {
+		for i := 0; i < 4; i++ {
+			val, ok := q.Read()
+			if !ok || val != lap*4+i {
+				t.Fatalf("Read() = %d, %t, want %d, true", val, ok, lap*4+i)
+			}
+			if !q.Write((lap+1)*4 + i) {
+				t.Fatal("Write() = false after Read")
+			}
+		}
+	}
+}
+
+func TestSPSCWriteNReadN(t *testing.T) {
+	q := NewSPSC[int](8)
+
+	// Move the indices so that batches wrap around the end of the buffer
+	q.WriteN(0, 0, 0, 0, 0, 0)
+	q.ReadN(make([]int, 6))
+
+	if n := q.WriteN(1, 2, 3, 4, 5); n != 5 {
+		t.Errorf("WriteN() = %d, want 5", n)
+	}
+	if n := q.WriteN(6, 7, 8, 9, 10); n != 3 {
+		t.Errorf("WriteN() on nearly full ring = %d, want 3", n)
+	}
+	if n := q.WriteN(11); n != 0 {
+		t.Errorf("WriteN() on full ring = %d, want 0", n)
+	}
+
+	dst := make([]int, 5)
+	if n := q.ReadN(dst); n != 5 || fmt.Sprint(dst) != "[1 2 3 4 5]" {
+		t.Errorf("ReadN() = %d, %v, want 5, [1 2 3 4 5]", n, dst)
+	}
+
+	dst = make([]int, 10)
+	if n := q.ReadN(dst); n != 3 || fmt.Sprint(dst[:n]) != "[6 7 8]" {
+		t.Errorf("ReadN() = %d, %v, want 3, [6 7 8]", n, dst[:n])
+	}
+	if n := q.ReadN(dst); n != 0 {
+		t.Errorf("ReadN() on empty ring = %d, want 0", n)
+	}
+}
+
+func TestSPSCConcurrent(t *testing.T) {
+	q := NewSPSC[int](64)
+	const total = 100000
+
+	go func() {
+		batch := make([]int, 0, 7)
+		for i := 0; i < total; {
+			if i%3 == 0 {
+				if q.Write(i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+				continue
+			}
+
+			batch = batch[:0]
+			for j := i; j < min(i+7, total); j++ {
+				batch = append(batch, j)
+			}
+			if n := q.WriteN(batch...); n > 0 {
+				i += n
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	dst := make([]int, 5)
+	for next := 0; next < total; {
+		if next%2 == 0 {
+			if val, ok := q.Read(); ok {
+				if val != next {
+					t.Fatalf("Read() = %d, want %d", val, next)
+				}
+				next++
+			} else {
+				runtime.Gosched()
+			}
+			continue
+		}
+
+		n := q.ReadN(dst)
+		if n == 0 {
+			runtime.Gosched()
+		}
+		for _, val := range dst[:n] {
+			if val != next {
+				t.Fatalf("ReadN() yielded %d, want %d", val, next)
+			}
+			next++
+		}
+	}
+
+	if q.Len() != 0 {
+		t.Errorf("Len() = %d, want 0", q.Len())
+	}
+}
+
+// BenchmarkSPSCPipeline transfers b.N values from one producer goroutine to one
+// consumer through the SPSC ring, deque.Deque (PushBack/PopFront) and
+// stack.Stack (Push/Pop)
+func BenchmarkSPSCPipeline(b *testing.B) {
+	const capacity = 1024
+
+	b.Run("SPSC", func(b *testing.B) {
+		q := NewSPSC[int](capacity)
+		go func() {
+			for i := 0; i < b.N; {
+				if q.Write(i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		for received := 0; received < b.N; {
+			if _, ok := q.Read(); ok {
+				received++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	})
+
+	b.Run("SPSC-Batch", func(b *testing.B) {
+		q := NewSPSC[int](capacity)
+		go func() {
+			batch := make([]int, 64)
+			for i := 0; i < b.N; {
+				if n := q.WriteN(batch[:min(len(batch), b.N-i)]...); n > 0 {
+					i += n
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		dst := make([]int, 64)
+		for received := 0; received < b.N; {
+			if n := q.ReadN(dst); n > 0 {
+				received += n
+			} else {
+				runtime.Gosched()
+			}
+		}
+	})
+
+	b.Run("Deque", func(b *testing.B) {
+		d := deque.NewBounded[int](capacity, deque.OverflowReject)
+		go func() {
+			for i := 0; i < b.N; {
+				if d.PushBack(i) == nil {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}()
+
+		for received := 0; received < b.N; {
+			if _, err := d.PopFront(); err == nil {
+				received++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	})
+
+	b.Run("Stack", func(b *testing.B) {
+		s := stack.New[int]()
+		go func() {
+			for i := 0; i < b.N; i++ {
+				s.Push(i)
+			}
+		}()
+
+		for received := 0; received < b.N; {
+			if _, ok := s.Pop(); ok {
+				received++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	})
+}
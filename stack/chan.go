@@ -0,0 +1,90 @@
+package stack
+
+import (
+	"context"
+	"time"
+)
+
+// FromChan returns a new stack that is filled from ch by a managed
+// goroutine, together with a channel that is closed once the goroutine
+// stops. The goroutine stops when ch is closed or ctx is done
+func FromChan[T any](ctx context.Context, ch <-chan T) (*Stack[T], <-chan struct{}) {
+	s := New[T]()
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case v, ok := <-ch:
+				if !ok {
+					return
+				}
+				s.Push(v)
+			}
+		}
+	}()
+
+	return s, done
+}
+
+// Bounds of the pause between polls of an empty stack in Chan
+const (
+	minChanBackoff = 50 * time.Microsecond
+	maxChanBackoff = 10 * time.Millisecond
+)
+
+// Chan returns a channel that receives the elements of the stack in
+// LIFO order, fed by a managed goroutine. The goroutine holds at most
+// one popped element while it waits for a receiver.
+//
+// The stack has no way to block until it is pushed to, so while it is
+// empty the goroutine polls it, backing off between polls. done signals
+// that the fill side is finished, as the channel returned by FromChan
+// does: once it is closed and the stack is empty, the channel is closed.
+// A nil done keeps the channel open until ctx is done. An element popped
+// but not yet delivered when ctx is done is pushed back
+func (s *Stack[T]) Chan(ctx context.Context, done <-chan struct{}) <-chan T {
+	out := make(chan T)
+
+	go func() {
+		defer close(out)
+
+		timer := time.NewTimer(0)
+		defer timer.Stop()
+		backoff := minChanBackoff
+
+		for ctx.Err() == nil {
+			val, ok := s.Pop()
+			if !ok {
+				timer.Reset(backoff)
+				select {
+				case <-ctx.Done():
+					return
+				case <-done:
+					// Every push of the fill side happened before done was
+					// closed, so an empty stack now stays empty
+					if s.Empty() {
+						return
+					}
+				case <-timer.C:
+					backoff = min(2*backoff, maxChanBackoff)
+				}
+				continue
+			}
+			backoff = minChanBackoff
+
+			select {
+			case out <- val:
+			case <-ctx.Done():
+				s.Push(val)
+				return
+			}
+		}
+	}()
+
+	return out
+}
@@ -0,0 +1,162 @@
+package stack
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestFromChan(t *testing.T) {
+	t.Run("Fill until channel is closed", func(t *testing.T) {
+		ch := make(chan int)
+		s, done := FromChan(context.Background(), ch)
+
+		for i := 0; i < 5; i++ {
+			ch <- i
+		}
+		close(ch)
+		<-done
+
+		if s.Size() != 5 {
+			t.Errorf("Size() = %d, want 5", s.Size())
+		}
+		val, ok := s.Pop()
+		if !ok || val != 4 {
+			t.Errorf("Pop() = %d, %t, want 4, true", val, ok)
+		}
+	})
+
+	t.Run("Stop on context cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int)
+		s, done := FromChan(ctx, ch)
+
+		ch <- 1
+		cancel()
+
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			t.Fatal("FromChan goroutine did not stop after cancel")
+		}
+		if s.Size() != 1 {
+			t.Errorf("Size() = %d, want 1", s.Size())
+		}
+	})
+}
+
+func TestStackChan(t *testing.T) {
+	t.Run("Deliver in LIFO order", func(t *testing.T) {
+		s := New[int]()
+		s.Push(1)
+		s.Push(2)
+		s.Push(3)
+
+		filled := make(chan struct{})
+		close(filled)
+
+		var result []int
+		for v := range s.Chan(context.Background(), filled) {
+			result = append(result, v)
+		}
+
+		expected := []int{3, 2, 1}
+		if len(result) != len(expected) {
+			t.Fatalf("Chan() yielded %v, want %v", result, expected)
+		}
+		for i := range expected {
+			if result[i] != expected[i] {
+				t.Errorf("Chan() yielded %v, want %v", result, expected)
+				break
+			}
+		}
+		if !s.Empty() {
+			t.Error("Empty() = false, want true")
+		}
+	})
+
+	t.Run("Push back undelivered element on cancel", func(t *testing.T) {
+		s := New[int]()
+		s.Push(1)
+		s.Push(2)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := s.Chan(ctx, nil)
+		if v := <-ch; v != 2 {
+			t.Errorf("received %d, want 2", v)
+		}
+
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		for range ch {
+		}
+
+		if s.Size() != 1 {
+			t.Errorf("Size() = %d, want 1", s.Size())
+		}
+		val, ok := s.Pop()
+		if !ok || val != 1 {
+			t.Errorf("Pop() = %d, %t, want 1, true", val, ok)
+		}
+	})
+	t.Run("Wait for a late producer", func(t *testing.T) {
+		ch := make(chan int)
+		s, filled := FromChan(context.Background(), ch)
+		out := s.Chan(context.Background(), filled)
+
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			for i := 0; i < 5; i++ {
+				ch <- i
+				time.Sleep(time.Millisecond)
+			}
+			close(ch)
+		}()
+
+		received := make(map[int]bool)
+		for v := range out {
+			received[v] = true
+		}
+
+		if len(received) != 5 {
+			t.Errorf("Chan() yielded %d distinct values, want 5", len(received))
+		}
+		for i := 0; i < 5; i++ {
+			if !received[i] {
+				t.Errorf("Chan() did not yield %d", i)
+			}
+		}
+	})
+
+	t.Run("Stay open while empty until cancel", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		s := New[int]()
+		out := s.Chan(ctx, nil)
+
+		select {
+		case v, ok := <-out:
+			t.Fatalf("received %d, %t from an empty stack", v, ok)
+		case <-time.After(20 * time.Millisecond):
+		}
+
+		s.Push(7)
+		select {
+		case v := <-out:
+			if v != 7 {
+				t.Errorf("received %d, want 7", v)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Chan() did not deliver an element pushed later")
+		}
+
+		cancel()
+		select {
+		case _, ok := <-out:
+			if ok {
+				t.Error("channel yielded a value after cancel")
+			}
+		case <-time.After(time.Second):
+			t.Fatal("channel was not closed after cancel")
+		}
+	})
+}
@@ -0,0 +1,127 @@
+package stack
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"sync/atomic"
+)
+
+// Option configures a Stack created by New
+type Option func(*config)
+
+// config holds the settings applied by Options
+type config struct {
+	eliminationWidth int
+}
+
+// WithElimination enables an elimination-backoff array of the given width
+// (Hendler, Shavit and Yerushalmi). When a CAS on the head fails because of
+// contention, Push and Pop try to meet an operation of the opposite kind in a
+// random slot of the array and exchange the value directly, without touching
+// the head. A width of zero or less picks one slot per two Ps
+func WithElimination(width int) Option {
+	return func(c *config) {
+		if width <= 0 {
+			width = max(runtime.GOMAXPROCS(0)/2, 1)
+		}
+		c.eliminationWidth = width
+	}
+}
+
+// eliminationSpins is the number of times an operation waiting in a slot
+// checks for a partner before it withdraws and retries on the head
+const eliminationSpins = 64
+
+const (
+	offerPush = iota + 1
+	offerPop
+)
+
+// offer is an operation waiting in an elimination slot for a partner
+type offer[T any] struct {
+	kind int
+	// value is set by the pusher: before publishing its own offer, or after
+	// taking a pop offer out of the slot and before marking it done
+	value T
+	done  atomic.Bool
+}
+
+// elimination is the side array where colliding pushes and pops meet
+type elimination[T any] struct {
+	slots []atomic.Pointer[offer[T]]
+}
+
+// newElimination creates an elimination array with width slots
+func newElimination[T any](width int) *elimination[T] {
+	return &elimination[T]{
+		slots: make([]atomic.Pointer[offer[T]], width),
+	}
+}
+
+// push tries to hand value to a concurrent pop.
+// It reports whether the value was taken
+func (e *elimination[T]) push(value T) bool {
+	slot := &e.slots[rand.IntN(len(e.slots))]
+
+	if other := slot.Load(); other != nil {
+		if other.kind != offerPop || !slot.CompareAndSwap(other, nil) {
+			return false
+		}
+
+		other.value = value
+		other.done.Store(true)
+		return true
+	}
+
+	mine := &offer[T]{kind: offerPush, value: value}
+	if !slot.CompareAndSwap(nil, mine) {
+		return false
+	}
+
+	for i := 0; i < eliminationSpins; i++ {
+		if mine.done.Load() {
+			return true
+		}
+		runtime.Gosched()
+	}
+
+	// Failing to withdraw means a pop has already taken the offer
+	return !slot.CompareAndSwap(mine, nil)
+}
+
+// pop tries to take a value from a concurrent push.
+// It returns the value and true on success
+func (e *elimination[T]) pop() (T, bool) {
+	slot := &e.slots[rand.IntN(len(e.slots))]
+
+	if other := slot.Load(); other != nil {
+		if other.kind != offerPush || !slot.CompareAndSwap(other, nil) {
+			return zeroval[T](), false
+		}
+
+		other.done.Store(true)
+		return other.value, true
+	}
+
+	mine := &offer[T]{kind: offerPop}
+	if !slot.CompareAndSwap(nil, mine) {
+		return zeroval[T](), false
+	}
+
+	for i := 0; i < eliminationSpins; i++ {
+		if mine.done.Load() {
+			return mine.value, true
+		}
+		runtime.Gosched()
+	}
+
+	if slot.CompareAndSwap(mine, nil) {
+		return zeroval[T](), false
+	}
+
+	// A push has taken the offer and is about to store its value
+	for !mine.done.Load() {
+		runtime.Gosched()
+	}
+	return mine.value, true
+}
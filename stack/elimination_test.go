@@ -0,0 +1,174 @@
+package stack
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWithElimination(t *testing.T) {
+	t.Run("Disabled by default", func(t *testing.T) {
+		s := New[int]()
+		if s.elim != nil {
+			t.Error("New() enabled elimination without WithElimination")
+		}
+	})
+
+	t.Run("Explicit width", func(t *testing.T) {
+		s := New[int](WithElimination(4))
+		if s.elim == nil || len(s.elim.slots) != 4 {
+			t.Error("WithElimination(4) did not create 4 slots")
+		}
+	})
+
+	t.Run("Default width", func(t *testing.T) {
+		s := New[int](WithElimination(0))
+		if s.elim == nil || len(s.elim.slots) < 1 {
+			t.Error("WithElimination(0) did not create any slots")
+		}
+	})
+
+	t.Run("Sequential behaviour unchanged", func(t *testing.T) {
+		s := New[int](WithElimination(2))
+		s.Push(1)
+		s.Push(2)
+		if s.Size() != 2 {
+			t.Errorf("Size() = %d, want 2", s.Size())
+		}
+		val, ok := s.Pop()
+		if !ok || val != 2 {
+			t.Errorf("Pop() = %d, %t, want 2, true", val, ok)
+		}
+	})
+}
+
+func TestEliminationExchange(t *testing.T) {
+	t.Run("Pop takes waiting push", func(t *testing.T) {
+		e := newElimination[int](1)
+		done := make(chan bool)
+
+		go func() {
+			done <- e.push(42)
+		}()
+
+		// Retry until the pusher has published its offer
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if val, ok := e.pop(); ok {
+				if val != 42 {
+					t.Errorf("pop() = %d, want 42", val)
+				}
+				if !<-done {
+					t.Error("push() = false after its value was taken")
+				}
+				return
+			}
+			runtime.Gosched()
+		}
+		t.Fatal("pop() never met the waiting push")
+	})
+
+	t.Run("Push fills waiting pop", func(t *testing.T) {
+		e := newElimination[int](1)
+		type result struct {
+			val int
+			ok  bool
+		}
+		done := make(chan result)
+
+		go func() {
+			for {
+				if val, ok := e.pop(); ok {
+					done <- result{val, ok}
+					return
+				}
+			}
+		}()
+
+		deadline := time.Now().Add(time.Second)
+		for time.Now().Before(deadline) {
+			if e.push(7) {
+				if r := <-done; r.val != 7 {
+					t.Errorf("pop() = %d, want 7", r.val)
+				}
+				return
+			}
+			runtime.Gosched()
+		}
+		t.Fatal("push() never met the waiting pop")
+	})
+
+	t.Run("Same kinds do not exchange", func(t *testing.T) {
+		e := newElimination[int](1)
+		if e.push(1) {
+			t.Error("push() = true without a partner")
+		}
+		if _, ok := e.pop(); ok {
+			t.Error("pop() = true without a partner")
+		}
+	})
+}
+
+func TestStressPushPopElimination(t *testing.T) {
+	stressPushPop(t, New[int](WithElimination(2)))
+}
+
+func TestEliminationConcurrentPairs(t *testing.T) {
+	s := New[int](WithElimination(1))
+	const numPairs = 8
+	const numOperations = 2000
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+
+	for p := 0; p < numPairs; p++ {
+		wg.Add(2)
+		go func(p int) {
+			defer wg.Done()
+			for j := 0; j < numOperations; j++ {
+				s.Push(p*numOperations + j)
+			}
+		}(p)
+		go func() {
+			defer wg.Done()
+			for popped := 0; popped < numOperations; {
+				if v, ok := s.Pop(); ok {
+					mu.Lock()
+					if seen[v] {
+						t.Errorf("value %d popped twice", v)
+					}
+					seen[v] = true
+					mu.Unlock()
+					popped++
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	if len(seen) != numPairs*numOperations {
+		t.Errorf("popped %d distinct values, want %d", len(seen), numPairs*numOperations)
+	}
+	if !s.Empty() {
+		t.Errorf("Size() = %d, want 0", s.Size())
+	}
+}
+
+func BenchmarkStackElimination(b *testing.B) {
+	cpuConfigs := []int{1, 2, 4, 8, runtime.NumCPU()}
+	operationMix := []int{pushOp, popOp}
+
+	for _, cpus := range cpuConfigs {
+		b.Run(fmt.Sprintf("Treiber/cpus=%d", cpus), func(b *testing.B) {
+			runtime.GOMAXPROCS(cpus)
+			benchmarkMixedOperations(b, cpus, operationMix)
+		})
+		b.Run(fmt.Sprintf("Elimination/cpus=%d", cpus), func(b *testing.B) {
+			runtime.GOMAXPROCS(cpus)
+			benchmarkMixedOperations(b, cpus, operationMix, WithElimination(0))
+		})
+	}
+}
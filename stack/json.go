@@ -0,0 +1,53 @@
+package stack
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// MarshalJSON implements json.Marshaler. The stack is encoded as a JSON
+// array of its elements from the top down, read from a single snapshot of
+// the head, so concurrent pushes and pops never produce a torn result
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	values := collect(s.head.Load(), nil)
+	if values == nil {
+		values = []T{}
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. It decodes a JSON array
+// whose first element is the top of the stack and atomically replaces the
+// contents of the stack with it, so concurrent operations observe either
+// the old or the new contents. A JSON null leaves the stack unchanged
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	const fancName = "(*Stack[T]).UnmarshalJSON"
+
+	if string(data) == "null" {
+		return nil
+	}
+
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("%s: %w", fancName, err)
+	}
+
+	if len(values) == 0 {
+		s.head.Store(nil)
+		return nil
+	}
+
+	// The chain puts its last value on top, while the array lists the
+	// top first
+	slices.Reverse(values)
+	top, _ := newChain(values)
+	depth := uint32(len(values))
+	for it := top; it != nil; it = it.next {
+		it.depth = depth
+		depth--
+	}
+
+	s.head.Store(top)
+	return nil
+}
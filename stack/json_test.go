@@ -0,0 +1,140 @@
+package stack
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int // pushed in order
+		expected string
+	}{
+		{"Empty stack", nil, `[]`},
+		{"Single value", []int{1}, `[1]`},
+		{"Top first", []int{1, 2, 3}, `[3,2,1]`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := New[int]()
+			s.PushN(tc.values...)
+
+			data, err := json.Marshal(s)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+			if string(data) != tc.expected {
+				t.Errorf("Marshal() = %s, want %s", data, tc.expected)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	s := New[int]()
+	s.Push(9)
+
+	if err := json.Unmarshal([]byte(`[3, 2, 1]`), s); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if s.Size() != 3 {
+		t.Errorf("Size() = %d, want 3", s.Size())
+	}
+	if n, ok := checkConsistent(s); !ok {
+		t.Errorf("inconsistent depths after %d nodes", n)
+	}
+
+	s.Push(4)
+	if got, want := s.PopAll(), []int{4, 3, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("PopAll() = %v, want %v", got, want)
+	}
+
+	s.Push(1)
+	if err := json.Unmarshal([]byte(`null`), s); err != nil {
+		t.Fatalf("Unmarshal(null) error = %v", err)
+	}
+	if s.Size() != 1 {
+		t.Errorf("Size() after null = %d, want 1", s.Size())
+	}
+
+	if err := json.Unmarshal([]byte(`[]`), s); err != nil {
+		t.Fatalf("Unmarshal([]) error = %v", err)
+	}
+	if !s.Empty() {
+		t.Errorf("Empty() = false after decoding an empty array")
+	}
+
+	if err := json.Unmarshal([]byte(`["a"]`), s); err == nil {
+		t.Errorf("Unmarshal() of wrong element type succeeded")
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	type point struct {
+		X, Y int
+	}
+
+	t.Run("Nested stacks", func(t *testing.T) {
+		s := New[*Stack[point]]()
+		for i := 0; i < 3; i++ {
+			inner := New[point]()
+			inner.PushN(point{i, 0}, point{i, 1})
+			s.Push(inner)
+		}
+
+		data, err := json.Marshal(s)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+
+		decoded := New[*Stack[point]]()
+		if err := json.Unmarshal(data, decoded); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+
+		again, err := json.Marshal(decoded)
+		if err != nil {
+			t.Fatalf("Marshal() of decoded stack error = %v", err)
+		}
+		if string(again) != string(data) {
+			t.Errorf("round trip = %s, want %s", again, data)
+		}
+
+		top, _ := decoded.Peek()
+		if val, ok := top.Pop(); !ok || val != (point{2, 1}) {
+			t.Errorf("top.Pop() = %v, %t, want {2 1}, true", val, ok)
+		}
+	})
+
+	t.Run("Struct field", func(t *testing.T) {
+		type payload struct {
+			History *Stack[map[string][]int] `json:"history"`
+		}
+
+		in := payload{History: New[map[string][]int]()}
+		in.History.Push(map[string][]int{"a": {1, 2}})
+		in.History.Push(map[string][]int{"b": nil})
+
+		data, err := json.Marshal(in)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if want := `{"history":[{"b":null},{"a":[1,2]}]}`; string(data) != want {
+			t.Errorf("Marshal() = %s, want %s", data, want)
+		}
+
+		var out payload
+		if err := json.Unmarshal(data, &out); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if out.History.Size() != 2 {
+			t.Fatalf("Size() = %d, want 2", out.History.Size())
+		}
+		if top, _ := out.History.Peek(); top["b"] != nil || len(top) != 1 {
+			t.Errorf("Peek() = %v, want map[b:[]]", top)
+		}
+	})
+}
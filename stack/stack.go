@@ -2,8 +2,24 @@
 //
 // The stack supports concurrent push, pop and size operations using atomic operations
 // rather than mutex locks, which can provide better performance in high-concurrency
-// scenarios. The implementation is based on a linked list using unsafe.Pointers
-// and atomic compare-and-swap (CAS) operations.
+// scenarios. The implementation is a Treiber stack: a linked list whose head is
+// swapped with atomic compare-and-swap (CAS) operations.
+//
+// The classic hazards of a Treiber stack do not apply here:
+//   - ABA: a CAS on the head can only be fooled if a node is freed and its memory
+//     reused while another goroutine still holds it. Every Push allocates a fresh
+//     node and nodes are never recycled, and the garbage collector does not reclaim
+//     a node while any goroutine still references it, so the head can never be
+//     swapped back to a pointer that refers to a different node.
+//   - Memory reclamation: the garbage collector plays the role of hazard pointers,
+//     so popped nodes are reclaimed only after every reader has let go of them.
+//   - Size consistency: every node records the depth of the stack below it, fixed
+//     before the node is published, so Size reads the depth of the current head and
+//     is linearizable with the CAS that installed it.
+//
+// Under heavy contention the stack can be created with WithElimination, which adds
+// an elimination-backoff array where colliding pushes and pops exchange values
+// directly instead of retrying on the head.
 //
 // The stack follows LIFO (Last-In-First-Out) semantics and is generic, working
 // with any type. Empty stack pops return the type's zero value.
@@ -18,37 +34,49 @@ package stack
 
 import (
 	"sync/atomic"
-	"unsafe"
 )
 
 // item represents a single element in the stack, containing a value and a pointer to the
-// next item
+// next item. An item is never modified once it has been published as the head
 type item[T any] struct {
 	value T
-	next  unsafe.Pointer
+	next  *item[T]
+	depth uint32 // number of items from this one to the bottom of the stack
 }
 
 // Stack is a thread-safe, generic LIFO (Last-In-First-Out) data structure implemented
 // using atomic operations.
 // It supports concurrent push and pop operations without locks
 type Stack[T any] struct {
-	head unsafe.Pointer
-	size atomic.Uint32
+	head atomic.Pointer[item[T]]
+	elim *elimination[T] // nil unless WithElimination is used
 }
 
 // New creates and returns a new, empty Stack for type T.
 // The stack is initialized with a nil head pointer
-func New[T any]() *Stack[T] {
-	return &Stack[T]{}
+func New[T any](opts ...Option) *Stack[T] {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	s := &Stack[T]{}
+	if cfg.eliminationWidth > 0 {
+		s.elim = newElimination[T](cfg.eliminationWidth)
+	}
+	return s
 }
 
-// Size returns the number of elements in the stack
+// Size returns the number of elements in the stack.
+// The result is linearizable: it is the exact size of the stack at the moment
+// the head was read
 func (s *Stack[T]) Size() uint32 {
-	return s.size.Load()
+	return depthOf(s.head.Load())
 }
 
+// Empty reports whether the stack has no elements
 func (s *Stack[T]) Empty() bool {
-	return s.size.Load() == 0
+	return s.head.Load() == nil
 }
 
 // Push adds a new value to the top of the stack.
@@ -59,11 +87,15 @@ func (s *Stack[T]) Push(value T) {
 	node := &item[T]{value: value}
 
 	for {
-		head := atomic.LoadPointer(&s.head)
+		head := s.head.Load()
 		node.next = head
+		node.depth = depthOf(head) + 1
+
+		if s.head.CompareAndSwap(head, node) {
+			return
+		}
 
-		if atomic.CompareAndSwapPointer(&s.head, head, unsafe.Pointer(node)) {
-			s.size.Add(1)
+		if s.elim != nil && s.elim.push(value) {
 			return
 		}
 	}
@@ -76,22 +108,127 @@ func (s *Stack[T]) Push(value T) {
 // concurrent access
 func (s *Stack[T]) Pop() (T, bool) {
 	for {
-		head := atomic.LoadPointer(&s.head)
+		head := s.head.Load()
 		if head == nil {
 			return zeroval[T](), false
 		}
 
-		next := atomic.LoadPointer(&(*item[T])(head).next)
-		if atomic.CompareAndSwapPointer(&s.head, head, next) {
+		if s.head.CompareAndSwap(head, head.next) {
+			return head.value, true
+		}
+
+		if s.elim != nil {
+			if value, ok := s.elim.pop(); ok {
+				return value, true
+			}
+		}
+	}
+}
+
+// PushN adds all values to the top of the stack with a single CAS, as if
+// Push was called for each of them in order: the last value ends up on top.
+// Concurrent operations observe either none or all of the values
+func (s *Stack[T]) PushN(values ...T) {
+	if len(values) == 0 {
+		return
+	}
+
+	top, bottom := newChain(values)
+
+	for {
+		head := s.head.Load()
+		bottom.next = head
+
+		depth := depthOf(head) + uint32(len(values))
+		for it := top; it != head; it = it.next {
+			it.depth = depth
+			depth--
+		}
+
+		if s.head.CompareAndSwap(head, top) {
+			return
+		}
+	}
+}
+
+// Peek returns the value at the top of the stack without removing it.
+// If the stack is empty, it returns the zero value of type T and false
+func (s *Stack[T]) Peek() (T, bool) {
+	head := s.head.Load()
+	if head == nil {
+		return zeroval[T](), false
+	}
+	return head.value, true
+}
+
+// PopN removes up to n values from the top of the stack with a single CAS and
+// returns them top first. It returns nil if the stack is empty or n is not positive
+func (s *Stack[T]) PopN(n int) []T {
+	if n <= 0 {
+		return nil
+	}
+
+	for {
+		head := s.head.Load()
+		if head == nil {
+			return nil
+		}
 
-			// Decrement length
-			s.size.Add(^uint32(0))
+		// Items are immutable once published, so the segment can be walked
+		// before it is detached
+		rest := head
+		for i := 0; i < n && rest != nil; i++ {
+			rest = rest.next
+		}
 
-			return (*item[T])(head).value, true
+		if s.head.CompareAndSwap(head, rest) {
+			return collect(head, rest)
 		}
 	}
 }
 
+// PopAll atomically removes every value from the stack and returns them top
+// first. It returns nil if the stack is empty
+func (s *Stack[T]) PopAll() []T {
+	return collect(s.head.Swap(nil), nil)
+}
+
+// newChain allocates an item for each of values and links them from the
+// last value down, so the last value is the top of the chain. Each item is
+// a separate allocation, so that a popped item does not keep the rest of
+// the chain reachable. The bottom item is left unlinked and the depths are
+// not set. values must not be empty
+func newChain[T any](values []T) (top, bottom *item[T]) {
+	bottom = &item[T]{value: values[0]}
+	top = bottom
+	for _, v := range values[1:] {
+		top = &item[T]{value: v, next: top}
+	}
+	return top, bottom
+}
+
+// collect returns the values of the items from first up to, but not including,
+// last
+func collect[T any](first, last *item[T]) []T {
+	if first == nil {
+		return nil
+	}
+
+	values := make([]T, 0, depthOf(first)-depthOf(last))
+	for node := first; node != last; node = node.next {
+		values = append(values, node.value)
+	}
+	return values
+}
+
+// depthOf returns the number of items from node to the bottom of the stack
+func depthOf[T any](node *item[T]) uint32 {
+	if node == nil {
+		return 0
+	}
+	return node.depth
+}
+
 // zeroval returns the zero value for type T.
 // This is used to return a valid value when popping from an empty stack
 func zeroval[T any]() T {
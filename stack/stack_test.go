@@ -161,6 +161,133 @@ func TestPop(t *testing.T) {
 	})
 }
 
+func TestPeek(t *testing.T) {
+	s := New[int]()
+	if val, ok := s.Peek(); ok || val != 0 {
+		t.Errorf("Peek() = %d, %t, want 0, false", val, ok)
+	}
+
+	s.Push(1)
+	s.Push(2)
+	if val, ok := s.Peek(); !ok || val != 2 {
+		t.Errorf("Peek() = %d, %t, want 2, true", val, ok)
+	}
+	if s.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", s.Size())
+	}
+}
+
+func TestPushN(t *testing.T) {
+	tests := []struct {
+		name     string
+		initial  []int
+		values   []int
+		expected []int // top first
+	}{
+		{"Empty batch", []int{1}, []int{}, []int{1}},
+		{"Batch on empty stack", nil, []int{1, 2, 3}, []int{3, 2, 1}},
+		{"Batch on top of existing values", []int{1, 2}, []int{3, 4}, []int{4, 3, 2, 1}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := New[int]()
+			for _, v := range tc.initial {
+				s.Push(v)
+			}
+
+			s.PushN(tc.values...)
+			if s.Size() != uint32(len(tc.expected)) {
+				t.Errorf("Size() = %d, want %d", s.Size(), len(tc.expected))
+			}
+
+			for _, want := range tc.expected {
+				val, ok := s.Pop()
+				if !ok || val != want {
+					t.Errorf("Pop() = %d, %t, want %d, true", val, ok, want)
+				}
+			}
+		})
+	}
+}
+
+func TestPushN_ReleasesPoppedItems(t *testing.T) {
+	type payload struct{ buf [1 << 10]byte }
+
+	s := New[*payload]()
+	released := make(chan struct{})
+	top := new(payload)
+	runtime.AddCleanup(top, func(struct{}) { close(released) }, struct{}{})
+
+	s.PushN(new(payload), top)
+	s.Pop()
+	top = nil
+
+	// The bottom item stays on the stack, and must not keep the popped
+	// one reachable
+	for i := 0; i < 10; i++ {
+		runtime.GC()
+		select {
+		case <-released:
+			if s.Size() != 1 {
+				t.Errorf("Size() = %d, want 1", s.Size())
+			}
+			return
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	t.Error("popped value is still reachable from the stack")
+}
+
+func TestPopN(t *testing.T) {
+	tests := []struct {
+		name         string
+		values       []int
+		n            int
+		expected     []int
+		expectedSize uint32
+	}{
+		{"Empty stack", nil, 2, nil, 0},
+		{"Zero count", []int{1, 2}, 0, nil, 2},
+		{"Negative count", []int{1, 2}, -1, nil, 2},
+		{"Fewer than available", []int{1, 2, 3}, 2, []int{3, 2}, 1},
+		{"Exactly available", []int{1, 2, 3}, 3, []int{3, 2, 1}, 0},
+		{"More than available", []int{1, 2}, 5, []int{2, 1}, 0},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s := New[int]()
+			s.PushN(tc.values...)
+
+			got := s.PopN(tc.n)
+			if fmt.Sprint(got) != fmt.Sprint(tc.expected) || (got == nil) != (tc.expected == nil) {
+				t.Errorf("PopN(%d) = %v, want %v", tc.n, got, tc.expected)
+			}
+			if s.Size() != tc.expectedSize {
+				t.Errorf("Size() = %d, want %d", s.Size(), tc.expectedSize)
+			}
+		})
+	}
+}
+
+func TestPopAll(t *testing.T) {
+	s := New[int]()
+	if got := s.PopAll(); got != nil {
+		t.Errorf("PopAll() = %v, want nil", got)
+	}
+
+	s.Push(1)
+	s.PushN(2, 3)
+	got := s.PopAll()
+	if fmt.Sprint(got) != fmt.Sprint([]int{3, 2, 1}) {
+		t.Errorf("PopAll() = %v, want [3 2 1]", got)
+	}
+	if !s.Empty() {
+		t.Errorf("Size() = %d, want 0", s.Size())
+	}
+}
+
 func TestStack(t *testing.T) {
 	type testCase[T any] struct {
 		name         string
@@ -351,6 +478,186 @@ func TestConcurrentPushPop(t *testing.T) {
 	}
 }
 
+// checkConsistent walks the stack from the current head and reports whether the
+// depth recorded in every node matches the number of nodes below it
+func checkConsistent[T any](s *Stack[T]) (uint32, bool) {
+	head := s.head.Load()
+	var n uint32
+	for node := head; node != nil; node = node.next {
+		if node.depth != depthOf(head)-n {
+			return n, false
+		}
+		n++
+	}
+	return n, n == depthOf(head)
+}
+
+func TestStressPushPop(t *testing.T) {
+	stressPushPop(t, New[int]())
+}
+
+// stressPushPop hammers s with interleaved Push, PushN, Pop and PopN calls on unique values and
+// checks that no value is lost or duplicated and that Size stays consistent
+func stressPushPop(t *testing.T, s *Stack[int]) {
+	const numRoutines = 16
+	const numOperations = 5000
+
+	var wg sync.WaitGroup
+	popped := make([][]int, numRoutines)
+
+	for r := 0; r < numRoutines; r++ {
+		wg.Add(1)
+		go func(r int) {
+			defer wg.Done()
+			localRand := rand.New(rand.NewSource(int64(r)))
+			for j := 0; j < numOperations; {
+				// Every value is unique, so losses and duplicates can be detected
+				if batch := min(localRand.Intn(4)+1, numOperations-j); batch > 1 {
+					values := make([]int, batch)
+					for k := range values {
+						values[k] = r*numOperations + j + k
+					}
+					s.PushN(values...)
+					j += batch
+				} else {
+					s.Push(r*numOperations + j)
+					j++
+				}
+
+				switch localRand.Intn(4) {
+				case 0:
+				case 1:
+					popped[r] = append(popped[r], s.PopN(2)...)
+				default:
+					if v, ok := s.Pop(); ok {
+						popped[r] = append(popped[r], v)
+					}
+				}
+			}
+		}(r)
+	}
+
+	// Size must never exceed the number of values pushed so far
+	stop := make(chan struct{})
+	checkerDone := make(chan struct{})
+	go func() {
+		defer close(checkerDone)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				if size := s.Size(); size > numRoutines*numOperations {
+					t.Errorf("Size() = %d, exceeds number of pushes", size)
+					return
+				}
+				if _, ok := checkConsistent(s); !ok {
+					t.Error("node depths disagree with stack contents")
+					return
+				}
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(stop)
+	<-checkerDone
+
+	remaining := s.Size()
+	seen := make([]bool, numRoutines*numOperations)
+	record := func(v int) {
+		if seen[v] {
+			t.Fatalf("value %d popped twice", v)
+		}
+		seen[v] = true
+	}
+	for _, values := range popped {
+		for _, v := range values {
+			record(v)
+		}
+	}
+
+	var drained uint32
+	for {
+		v, ok := s.Pop()
+		if !ok {
+			break
+		}
+		record(v)
+		drained++
+	}
+
+	if drained != remaining {
+		t.Errorf("drained %d values, Size() reported %d", drained, remaining)
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("value %d was lost", v)
+		}
+	}
+}
+
+func TestStressSizeLinearizable(t *testing.T) {
+	s := New[int]()
+	const numRoutines = 8
+	const numOperations = 2000
+
+	var wg sync.WaitGroup
+	for r := 0; r < numRoutines; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOperations; j++ {
+				s.Push(j)
+			}
+		}()
+	}
+
+	// While only pushes are running Size can only grow
+	var last uint32
+	for {
+		size := s.Size()
+		if size < last {
+			t.Fatalf("Size() went from %d to %d during pushes", last, size)
+		}
+		last = size
+		if size == numRoutines*numOperations {
+			break
+		}
+	}
+	wg.Wait()
+
+	for r := 0; r < numRoutines; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < numOperations; j++ {
+				if _, ok := s.Pop(); !ok {
+					t.Error("Pop() returned false on a non-empty stack")
+					return
+				}
+			}
+		}()
+	}
+
+	// While only pops are running Size can only shrink
+	for {
+		size := s.Size()
+		if size > last {
+			t.Fatalf("Size() went from %d to %d during pops", last, size)
+		}
+		last = size
+		if size == 0 {
+			break
+		}
+	}
+	wg.Wait()
+
+	if !s.Empty() {
+		t.Error("Empty() = false, want true")
+	}
+}
+
 func BenchmarkStack_Pop(b *testing.B) {
 	sizes := []int{1, 10, 100, 1000, 2000}
 
@@ -399,8 +706,8 @@ func BenchmarkStackMixedOperations(b *testing.B) {
 	}
 }
 
-func benchmarkMixedOperations(b *testing.B, cpus int, operationMix []int) {
-	s := New[int]()
+func benchmarkMixedOperations(b *testing.B, cpus int, operationMix []int, opts ...Option) {
+	s := New[int](opts...)
 
 	b.ResetTimer()
 